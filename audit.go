@@ -0,0 +1,81 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AuditPage checks that the sorted page of IDs is consistent with the stepSize, so it catches IDs written by a client
+// with another configuration. It's stricter than checking the order: every ID must be valid and greater than the previous one,
+// and for IDs of the same length the distance (see CountBetween, plus one) must be either a multiple of stepSize
+// or less than stepSize, which happens when IDs were inserted between the steps.
+// IDs of different lengths are only checked for the order, as the length grows when a gap is exhausted.
+// Deleted IDs are not allowed for: a step with a removed insert leaves a distance that is not a multiple of stepSize.
+func (l Lexid) AuditPage(ids []string) error {
+	for i, id := range ids {
+		if err := l.Validate(id); err != nil {
+			return fmt.Errorf("id at %d: %w", i, err)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := ids[i-1]
+		if !l.less(prev, id) {
+			return fmt.Errorf("%w: id at %d '%s' is not greater than '%s'", ErrNotSorted, i, id, prev)
+		}
+		a, b := l.regularPair(prev, id)
+		if len(a) != len(b) {
+			continue
+		}
+		dist := l.distance(a, b)
+		step := big.NewInt(int64(l.stepSize))
+		if dist.Cmp(step) > 0 && new(big.Int).Rem(dist, step).Sign() != 0 {
+			return fmt.Errorf("%w: id at %d '%s' is %s IDs after '%s', it's not reachable by steps of %d",
+				ErrInvalidID, i, id, dist, prev, l.stepSize)
+		}
+	}
+	return nil
+}
+
+// Steps returns the number of Next calls from a to b, so the IDs belong to the same series of stepSize increments.
+// It returns 0 for equal IDs and an error if an ID is not valid, b is less than a, the IDs have different lengths
+// or the distance between them is not a multiple of stepSize.
+func (l Lexid) Steps(a, b string) (*big.Int, error) {
+	if err := l.Validate(a); err != nil {
+		return nil, err
+	}
+	if err := l.Validate(b); err != nil {
+		return nil, err
+	}
+	if a == b {
+		return new(big.Int), nil
+	}
+	if !l.less(a, b) {
+		return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, b, a)
+	}
+	ra, rb := l.regularPair(a, b)
+	if len(ra) != len(rb) {
+		return nil, fmt.Errorf("%w: '%s' and '%s' have different lengths", ErrInvalidID, a, b)
+	}
+	steps, rem := new(big.Int).QuoRem(l.distance(ra, rb), big.NewInt(int64(l.stepSize)), new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, fmt.Errorf("%w: '%s' is not reachable from '%s' by steps of %d", ErrInvalidID, b, a, l.stepSize)
+	}
+	return steps, nil
+}
+
+// distance returns the difference of the indexes of the regular ids a < b among the valid IDs of the length
+func (l Lexid) distance(a, b string) *big.Int {
+	dist := l.regular().CountBetween(a, b)
+	return dist.Add(dist, big.NewInt(1))
+}
+
+// regularPair returns the valid ids a < b without the namespace in the regular ordering
+func (l Lexid) regularPair(a, b string) (string, string) {
+	a, b = a[len(l.namespace):], b[len(l.namespace):]
+	if l.shortGreater {
+		// the mirror reverses the order
+		return l.fromShortGreater(b), l.fromShortGreater(a)
+	}
+	return a, b
+}
@@ -0,0 +1,91 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_AuditPage(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10)
+
+	t.Run("steps", func(t *testing.T) {
+		ids := lid.GenerateAfter("", 50)
+		assert.NoError(t, lid.AuditPage(ids))
+		assert.NoError(t, lid.AuditPage(nil))
+		assert.NoError(t, lid.AuditPage(ids[:1]))
+		// a skipped step is still a multiple of stepSize
+		assert.NoError(t, lid.AuditPage([]string{ids[0], ids[3], ids[4]}))
+	})
+	t.Run("inserts", func(t *testing.T) {
+		ids := lid.GenerateAfter("", 5)
+		mid, err := lid.NextBefore(ids[1], ids[2])
+		require.NoError(t, err)
+		page := []string{ids[0], ids[1], mid, ids[2], lid.Next(ids[2])}
+		assert.NoError(t, lid.AuditPage(page))
+		after := lid.Next(mid)
+		assert.NoError(t, lid.AuditPage([]string{ids[1], mid, after}))
+	})
+	t.Run("growth", func(t *testing.T) {
+		assert.NoError(t, lid.AuditPage([]string{"zzq", lid.Next("zzq"), lid.Next(lid.Next("zzq"))}))
+	})
+	t.Run("another step", func(t *testing.T) {
+		other := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 13)
+		err := lid.AuditPage(other.GenerateAfter("", 5))
+		assert.ErrorIs(t, err, ErrInvalidID)
+		assert.Contains(t, err.Error(), "id at 1")
+	})
+	t.Run("order", func(t *testing.T) {
+		assert.ErrorIs(t, lid.AuditPage([]string{"00l", "00b"}), ErrNotSorted)
+		assert.ErrorIs(t, lid.AuditPage([]string{"00b", "00b"}), ErrNotSorted)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		err := lid.AuditPage([]string{"00b", "00l0"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		assert.Contains(t, err.Error(), "id at 1")
+	})
+	t.Run("namespace and short greater", func(t *testing.T) {
+		sg, err := New("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithShortGreater(true))
+		require.NoError(t, err)
+		for _, l := range []*Lexid{lid.WithNamespace("ns1"), sg} {
+			ids := l.GenerateAfter("", 20)
+			assert.NoError(t, l.AuditPage(ids))
+			bad := *Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 13)
+			bad.namespace, bad.shortGreater = l.namespace, l.shortGreater
+			assert.ErrorIs(t, l.AuditPage(bad.GenerateAfter("", 5)), ErrInvalidID)
+		}
+	})
+}
+
+func TestLexid_Steps(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10)
+	ids := lid.GenerateAfter("", 300)
+	for _, n := range []int{0, 1, 2, 100, 299} {
+		steps, err := lid.Steps(ids[0], ids[n])
+		require.NoError(t, err)
+		assert.Equal(t, int64(n), steps.Int64())
+	}
+	// over the skipped trailing lower char
+	steps, err := lid.Steps("00v", "016")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), steps.Int64())
+
+	_, err = lid.Steps("00b", "00c")
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = lid.Steps("00l", "00b")
+	assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	_, err = lid.Steps("zzq", lid.Next("zzq"))
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = lid.Steps("00b", "00l0")
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	t.Run("short greater", func(t *testing.T) {
+		sg, err := New("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithShortGreater(true))
+		require.NoError(t, err)
+		ids := sg.GenerateAfter("", 20)
+		steps, err := sg.Steps(ids[2], ids[19])
+		require.NoError(t, err)
+		assert.Equal(t, int64(17), steps.Int64())
+	})
+}
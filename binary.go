@@ -0,0 +1,78 @@
+package lexid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MarshalBinarySlice encodes the sorted IDs with front coding: every ID is stored as the length of the prefix shared
+// with the previous ID and the remaining suffix, so deep keys of a sorted list take little space.
+// The format is uvarint count followed by uvarint shared, uvarint suffix length and the suffix bytes for every ID.
+// It returns an error if an ID is not valid or the IDs are not sorted.
+func (l Lexid) MarshalBinarySlice(ids []string) ([]byte, error) {
+	if err := l.checkArray(ids); err != nil {
+		return nil, err
+	}
+	buf := appendUvarint(nil, uint64(len(ids)))
+	prev := ""
+	for _, id := range ids {
+		shared := 0
+		for shared < len(prev) && shared < len(id) && prev[shared] == id[shared] {
+			shared++
+		}
+		buf = appendUvarint(buf, uint64(shared))
+		buf = appendUvarint(buf, uint64(len(id)-shared))
+		buf = append(buf, id[shared:]...)
+		prev = id
+	}
+	return buf, nil
+}
+
+// UnmarshalBinarySlice decodes the IDs encoded by MarshalBinarySlice.
+// It returns an error if the data is malformed, an ID is not valid or the IDs are not sorted.
+func (l Lexid) UnmarshalBinarySlice(data []byte) ([]string, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: malformed slice header", ErrInvalidID)
+	}
+	data = data[n:]
+	// every id takes at least two bytes, so a bigger count is malformed and must not be allocated
+	if count > uint64(len(data)/2) {
+		return nil, fmt.Errorf("%w: malformed slice: %d ids in %d bytes", ErrInvalidID, count, len(data))
+	}
+	var ids []string
+	if count > 0 {
+		ids = make([]string, 0, count)
+	}
+	prev := ""
+	for i := uint64(0); i < count; i++ {
+		shared, n := binary.Uvarint(data)
+		if n <= 0 || shared > uint64(len(prev)) {
+			return nil, fmt.Errorf("%w: malformed shared prefix of id at %d", ErrInvalidID, i)
+		}
+		data = data[n:]
+		size, n := binary.Uvarint(data)
+		if n <= 0 || size > uint64(len(data)-n) {
+			return nil, fmt.Errorf("%w: malformed suffix of id at %d", ErrInvalidID, i)
+		}
+		data = data[n:]
+		id := prev[:shared] + string(data[:size])
+		data = data[size:]
+		ids = append(ids, id)
+		prev = id
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes after the slice", ErrInvalidID, len(data))
+	}
+	if err := l.checkArray(ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// appendUvarint appends the uvarint encoded v to buf
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
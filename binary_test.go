@@ -0,0 +1,53 @@
+package lexid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_MarshalBinarySlice(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("round trip", func(t *testing.T) {
+		prefix := strings.Repeat("abc", 10)
+		var deep []string
+		for _, id := range lid.GenerateAfter("", 100) {
+			deep = append(deep, prefix+id)
+		}
+		for _, ids := range [][]string{nil, {"001"}, lid.GenerateAfter("", 1000), deep, {"001", "001i01", "001i01i01", "002"}} {
+			data, err := lid.MarshalBinarySlice(ids)
+			require.NoError(t, err)
+			res, err := lid.UnmarshalBinarySlice(data)
+			require.NoError(t, err)
+			assert.Equal(t, ids, res)
+		}
+		data, err := lid.MarshalBinarySlice(deep)
+		require.NoError(t, err)
+		// the shared prefix is stored once
+		assert.Less(t, len(data), len(deep)*6)
+	})
+	t.Run("invalid ids", func(t *testing.T) {
+		_, err := lid.MarshalBinarySlice([]string{"002", "001"})
+		assert.ErrorIs(t, err, ErrNotSorted)
+		_, err = lid.MarshalBinarySlice([]string{"001", "0010"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("not sorted data", func(t *testing.T) {
+		data, err := lid.MarshalBinarySlice([]string{"001", "002"})
+		require.NoError(t, err)
+		// replace the last suffix char to make the ids equal
+		data[len(data)-1] = '1'
+		_, err = lid.UnmarshalBinarySlice(data)
+		assert.ErrorIs(t, err, ErrNotSorted)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		data, err := lid.MarshalBinarySlice([]string{"001", "002"})
+		require.NoError(t, err)
+		for _, bad := range [][]byte{nil, {0x80}, data[:len(data)-1], append(append([]byte{}, data...), 0), {2, 1, 3, '0', '0', '1', 0, 0}, {200, 0}} {
+			_, err := lid.UnmarshalBinarySlice(bad)
+			assert.ErrorIs(t, err, ErrInvalidID, bad)
+		}
+	})
+}
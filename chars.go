@@ -0,0 +1,31 @@
+package lexid
+
+import "fmt"
+
+// CheckChars returns an error if chars are not safe to use as an alphabet:
+// there are fewer than two unique characters, there are non-printable bytes (control chars),
+// or there are bytes which are ambiguous (space and non-ASCII bytes, which may be parts of multibyte UTF-8 characters
+// sorted by bytes rather than by characters). It doesn't require a Lexid and can be used as a startup lint.
+func CheckChars(chars string) error {
+	var unique [256]bool
+	var uniqueCount int
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		switch {
+		case c < ' ' || c == 0x7f:
+			return fmt.Errorf("%w: non-printable byte 0x%02x at %d", ErrInvalidChars, c, i)
+		case c == ' ':
+			return fmt.Errorf("%w: ambiguous space at %d", ErrInvalidChars, i)
+		case c > 0x7f:
+			return fmt.Errorf("%w: ambiguous non-ASCII byte 0x%02x at %d", ErrInvalidChars, c, i)
+		}
+		if !unique[c] {
+			unique[c] = true
+			uniqueCount++
+		}
+	}
+	if uniqueCount < 2 {
+		return fmt.Errorf("%w: chars must contain at least two unique characters", ErrInvalidChars)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckChars(t *testing.T) {
+	for _, chars := range []string{CharsAll, CharsAllNoEscape, CharsAlphanumeric, CharsAlphanumericLower, CharsBase64, CharsBase58, CharsUnambiguous, "ab", "aab"} {
+		assert.NoError(t, CheckChars(chars), chars)
+	}
+	for _, chars := range []string{"", "a", "aaa", "ab\n", "ab\x00", "ab\x7f", "a b", "abé"} {
+		assert.Error(t, CheckChars(chars), chars)
+	}
+}
+
+func TestCharsUnambiguous(t *testing.T) {
+	assert.True(t, sort.SliceIsSorted([]byte(CharsUnambiguous), func(i, j int) bool {
+		return CharsUnambiguous[i] < CharsUnambiguous[j]
+	}))
+	for _, c := range "0O1lI" {
+		assert.NotContains(t, CharsUnambiguous, string(c))
+	}
+	// all other alphanumeric chars are kept
+	assert.Len(t, CharsUnambiguous, len(CharsAlphanumeric)-5)
+	lid := Must(CharsUnambiguous, 3, 10)
+	assert.Equal(t, "22D", lid.Next(""))
+	assert.True(t, lid.IsValid(lid.Next("")))
+}
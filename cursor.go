@@ -0,0 +1,42 @@
+package lexid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// cursorMagic prefixes the payload of cursors to distinguish them from other tokens
+const cursorMagic = "lx1"
+
+// EncodeCursor wraps the id into an opaque URL-safe pagination token with a magic prefix and a checksum.
+// The checksum detects tampered or corrupted tokens, but it's not a cryptographic signature.
+func (l Lexid) EncodeCursor(id string) string {
+	payload := make([]byte, len(cursorMagic)+len(id)+4)
+	n := copy(payload, cursorMagic)
+	n += copy(payload[n:], id)
+	binary.BigEndian.PutUint32(payload[n:], crc32.ChecksumIEEE(payload[:n]))
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor returns the id from a token created by EncodeCursor.
+// It returns an error if the token is malformed, the checksum doesn't match or the id is not valid for the Lexid.
+func (l Lexid) DecodeCursor(tok string) (string, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor: %v", ErrInvalidID, err)
+	}
+	if len(payload) < len(cursorMagic)+4 || string(payload[:len(cursorMagic)]) != cursorMagic {
+		return "", fmt.Errorf("%w: malformed cursor", ErrInvalidID)
+	}
+	data, sum := payload[:len(payload)-4], payload[len(payload)-4:]
+	if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(sum) {
+		return "", fmt.Errorf("%w: cursor checksum mismatch", ErrInvalidID)
+	}
+	id := string(data[len(cursorMagic):])
+	if err = l.Validate(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,44 @@
+package lexid
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Cursor(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("round trip", func(t *testing.T) {
+		var prev string
+		for i := 0; i < 100; i++ {
+			prev = lid.Next(prev)
+			tok := lid.EncodeCursor(prev)
+			assert.NotContains(t, tok, prev)
+			id, err := lid.DecodeCursor(tok)
+			require.NoError(t, err)
+			assert.Equal(t, prev, id)
+		}
+	})
+	t.Run("tampered", func(t *testing.T) {
+		tok := lid.EncodeCursor("abc001")
+		payload, err := base64.RawURLEncoding.DecodeString(tok)
+		require.NoError(t, err)
+		payload[4]++
+		_, err = lid.DecodeCursor(base64.RawURLEncoding.EncodeToString(payload))
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		for _, tok := range []string{"", "!!!", base64.RawURLEncoding.EncodeToString([]byte("abc001abcd"))} {
+			_, err := lid.DecodeCursor(tok)
+			assert.ErrorIs(t, err, ErrInvalidID, tok)
+		}
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := lid.DecodeCursor(lid.EncodeCursor("ABC"))
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.DecodeCursor(Must(CharsAlphanumeric, 3, 10).EncodeCursor("ABC"))
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+}
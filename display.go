@@ -0,0 +1,50 @@
+package lexid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithDisplaySeparator sets the separator that Format inserts between blocks.
+// The separator must not be in the alphabet, so Unformat can strip it safely.
+func WithDisplaySeparator(sep byte) Option {
+	return func(l *Lexid) error {
+		if sep == 0 {
+			return fmt.Errorf("%w: display separator must not be zero", ErrInvalidConfig)
+		}
+		if l.charIndex[sep] != -1 {
+			return fmt.Errorf("%w: display separator '%c' is in the alphabet", ErrInvalidConfig, sep)
+		}
+		l.displaySep = sep
+		return nil
+	}
+}
+
+// Format returns the id with the display separator between blocks. If no separator is set, the id is returned as is.
+// The formatted form is for display only, store and compare the raw id.
+func (l Lexid) Format(id string) string {
+	if l.displaySep == 0 || len(id) <= l.blockSize {
+		return id
+	}
+	var b strings.Builder
+	b.Grow(len(id) + len(id)/l.blockSize)
+	for i := 0; i < len(id); i += l.blockSize {
+		if i > 0 {
+			b.WriteByte(l.displaySep)
+		}
+		end := i + l.blockSize
+		if end > len(id) {
+			end = len(id)
+		}
+		b.WriteString(id[i:end])
+	}
+	return b.String()
+}
+
+// Unformat removes the display separators added by Format
+func (l Lexid) Unformat(s string) string {
+	if l.displaySep == 0 {
+		return s
+	}
+	return strings.ReplaceAll(s, string(l.displaySep), "")
+}
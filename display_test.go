@@ -0,0 +1,40 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Format(t *testing.T) {
+	t.Run("invalid separator", func(t *testing.T) {
+		_, err := New(CharsAlphanumericLower, 3, 1, WithDisplaySeparator('a'))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = New(CharsAlphanumericLower, 3, 1, WithDisplaySeparator(0))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+	t.Run("no separator", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Equal(t, "001002", lid.Format("001002"))
+		assert.Equal(t, "001-002", lid.Unformat("001-002"))
+	})
+	lid := Must(CharsAlphanumericLower, 3, 10, WithDisplaySeparator('-'))
+	t.Run("format", func(t *testing.T) {
+		assert.Equal(t, "", lid.Format(""))
+		assert.Equal(t, "001", lid.Format("001"))
+		assert.Equal(t, "001-002", lid.Format("001002"))
+		assert.Equal(t, "001-002-zzz", lid.Format("001002zzz"))
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var id string
+		for i := 0; i < 10000; i++ {
+			id = lid.Next(id)
+			assert.Equal(t, id, lid.Unformat(lid.Format(id)))
+		}
+		id = "zzzzzz"
+		for i := 0; i < 100; i++ {
+			id = lid.Prev(id)
+			assert.Equal(t, id, lid.Unformat(lid.Format(id)))
+		}
+	})
+}
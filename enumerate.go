@@ -0,0 +1,61 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// enumerateMax is the max number of IDs returned by Enumerate
+const enumerateMax = 1 << 20
+
+// Capacity returns the number of valid single block IDs: all blocks except those ending with the lower char,
+// unless they are allowed by WithAllowTrailingMin
+func (l Lexid) Capacity() *big.Int {
+	return l.validCount(1)
+}
+
+// CountAtLength returns the number of valid IDs of exactly blocks*blockSize chars without the namespace.
+// It's len(chars)^length minus the values ending with the lower char, unless they are allowed by WithAllowTrailingMin.
+// It returns 0 for non-positive blocks.
+func (l Lexid) CountAtLength(blocks int) *big.Int {
+	if blocks <= 0 {
+		return new(big.Int)
+	}
+	return l.validCount(blocks)
+}
+
+// Enumerate returns all valid single block IDs in ascending order, the min length is ignored.
+// It returns an error if there are more than 2^20 IDs, check Capacity first for big alphabets or blocks.
+func (l Lexid) Enumerate() ([]string, error) {
+	capacity := l.Capacity()
+	if capacity.Cmp(big.NewInt(enumerateMax)) > 0 {
+		return nil, fmt.Errorf("%w: %s ids is more than the enumeration limit %d", ErrInvalidConfig, abbreviateInt(capacity), enumerateMax)
+	}
+	regular := l.withoutNamespace()
+	regular.shortGreater = false
+	regular.minLength = 0
+	ids := make([]string, 0, capacity.Int64())
+	first := regular.padding("", l.blockSize)
+	if l.trailingMin {
+		first = strings.Repeat(string(l.lower), l.blockSize)
+	}
+	for id, grew := first, false; !grew; id, grew = regular.nextStepInfo(id, 1) {
+		ids = append(ids, id)
+	}
+	if l.shortGreater {
+		// the transformation reverses the order
+		for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+			ids[i], ids[j] = ids[j], ids[i]
+		}
+		for i := range ids {
+			ids[i] = l.toShortGreater(ids[i])
+		}
+	}
+	if l.namespace != "" {
+		for i := range ids {
+			ids[i] = l.namespace + ids[i]
+		}
+	}
+	return ids, nil
+}
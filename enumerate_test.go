@@ -0,0 +1,69 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Enumerate(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		lid := Must("012", 2, 1)
+		ids, err := lid.Enumerate()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"01", "02", "11", "12", "21", "22"}, ids)
+		assert.Equal(t, int64(6), lid.Capacity().Int64())
+	})
+	t.Run("trailing min", func(t *testing.T) {
+		lid := Must("012", 2, 1, WithAllowTrailingMin(true))
+		ids, err := lid.Enumerate()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"00", "01", "02", "10", "11", "12", "20", "21", "22"}, ids)
+		assert.Equal(t, int64(9), lid.Capacity().Int64())
+		assert.Equal(t, lid.CountAtLength(1), lid.Capacity())
+	})
+	for _, lid := range []*Lexid{
+		Must(CharsAlphanumericLower, 3, 10),
+		Must(CharsAlphanumericLower, 2, 1).WithNamespace("ab"),
+		Must(CharsAlphanumericLower, 2, 1, WithShortGreater(true)),
+		Must(CharsAlphanumericLower, 2, 1, WithAllowTrailingMin(true)),
+		Must(CharsAlphanumericLower, 3, 10, WithAllowTrailingMin(true)),
+	} {
+		ids, err := lid.Enumerate()
+		require.NoError(t, err)
+		assert.Equal(t, lid.Capacity().Int64(), int64(len(ids)))
+		assert.True(t, sort.StringsAreSorted(ids))
+		for i, id := range ids {
+			require.True(t, lid.IsValid(id), id)
+			if i > 0 {
+				require.NotEqual(t, ids[i-1], id)
+			}
+		}
+	}
+	t.Run("too many", func(t *testing.T) {
+		_, err := Must(CharsAlphanumericLower, 5, 1).Enumerate()
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestLexid_CountAtLength(t *testing.T) {
+	lid := Must("0123", 2, 1)
+	assert.Equal(t, int64(0), lid.CountAtLength(0).Int64())
+	assert.Equal(t, int64(12), lid.CountAtLength(1).Int64())
+	assert.Equal(t, int64(192), lid.CountAtLength(2).Int64())
+	assert.Equal(t, lid.Capacity(), lid.CountAtLength(1))
+
+	ids, err := lid.Enumerate()
+	require.NoError(t, err)
+	assert.Len(t, ids, int(lid.CountAtLength(1).Int64()))
+
+	trailing := Must("0123", 2, 1, WithAllowTrailingMin(true))
+	assert.Equal(t, int64(16), trailing.CountAtLength(1).Int64())
+	assert.Equal(t, int64(256), trailing.CountAtLength(2).Int64())
+
+	// the exact count doesn't fit in uint64
+	wide := Must(CharsBase64, 8, 1)
+	assert.Equal(t, "77990222474978957318644826112", wide.CountAtLength(2).String())
+}
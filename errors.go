@@ -0,0 +1,24 @@
+package lexid
+
+import "errors"
+
+var (
+	// ErrInvalidChars is returned when chars can't be used as an alphabet
+	ErrInvalidChars = errors.New("invalid chars")
+	// ErrInvalidConfig is returned for invalid blockSize, stepSize or options
+	ErrInvalidConfig = errors.New("invalid config")
+	// ErrInvalidID is returned when an ID is malformed or doesn't belong to the generator
+	ErrInvalidID = errors.New("invalid id")
+	// ErrBeforeNotGreater is returned when the upper bound is less or equal to the lower bound
+	ErrBeforeNotGreater = errors.New("before is not greater than prev")
+	// ErrNoMidpoint is returned when an ID between two bounds can't be created
+	ErrNoMidpoint = errors.New("unable to create id between bounds")
+	// ErrExhausted is returned when there are no more IDs without growing the length
+	ErrExhausted = errors.New("ids are exhausted")
+	// ErrMaxLength is returned when an ID would exceed the max length
+	ErrMaxLength = errors.New("max length exceeded")
+	// ErrNotSorted is returned when IDs are expected to be sorted but they are not
+	ErrNotSorted = errors.New("ids are not sorted")
+	// ErrOutOfRange is returned when an index or rank argument is out of its range
+	ErrOutOfRange = errors.New("out of range")
+)
@@ -0,0 +1,42 @@
+package lexid
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1, WithMaxLength(6))
+	_, err := New("a", 3, 1)
+	assert.ErrorIs(t, err, ErrInvalidChars)
+	_, err = New("01", 2, 4)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewStrict("01", 0, 1)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = lid.NextBefore("002", "001")
+	assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	_, err = lid.NextBeforeN("002", "001", 2)
+	assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	_, err = lid.NextBefore("001001", "001002")
+	assert.ErrorIs(t, err, ErrMaxLength)
+	_, err = lid.NextFixed("zzz")
+	assert.ErrorIs(t, err, ErrExhausted)
+	_, err = lid.Rebalance([]string{"002", "001"})
+	assert.ErrorIs(t, err, ErrNotSorted)
+	_, _, err = lid.PrefixRange("A")
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = NewFractionalCompat().Between("a00", "")
+	assert.ErrorIs(t, err, ErrInvalidID)
+	assert.ErrorIs(t, CheckChars("a\n"), ErrInvalidChars)
+	_, err = lid.InsertKey([]string{"001"}, 2)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.NotErrorIs(t, err, ErrInvalidConfig)
+	_, err = lid.KeyAtRank([]string{"001"}, 1.5)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.NotErrorIs(t, err, ErrInvalidConfig)
+	_, err = PBID("abc").MarshalTo(make([]byte, 2))
+	assert.ErrorIs(t, err, io.ErrShortBuffer)
+	assert.NotErrorIs(t, err, ErrInvalidConfig)
+}
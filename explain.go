@@ -0,0 +1,49 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Explain describes the structure of the id for debugging: the blocks with their numeric values,
+// whether the last block is the padding block appended when a gap was exhausted, like "001",
+// and the position of the id in the keyspace as a percentage, where the first block is the most significant.
+// The format is stable, for example:
+//
+//	'a0z000001': 3 blocks [a0z 000 001], values [12995 0 1], padding block: true, position: 27.85%
+//
+// Invalid ids are described by the validation error.
+func (l Lexid) Explain(id string) string {
+	if err := l.Validate(id); err != nil {
+		return fmt.Sprintf("'%s': invalid: %v", id, err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "'%s': ", id)
+	regular := id
+	if l.namespace != "" {
+		fmt.Fprintf(&sb, "namespace '%s', ", l.namespace)
+		regular = regular[len(l.namespace):]
+	}
+	if l.shortGreater {
+		regular = l.fromShortGreater(regular)
+		fmt.Fprintf(&sb, "regular '%s', ", regular)
+	}
+	blocks := make([]string, 0, len(regular)/l.blockSize)
+	values := make([]string, 0, cap(blocks))
+	for i := 0; i < len(regular); i += l.blockSize {
+		block := regular[i : i+l.blockSize]
+		blocks = append(blocks, block)
+		values = append(values, l.toInt(block).String())
+	}
+	padding := len(blocks) > 1 && blocks[len(blocks)-1] == l.padding("", l.blockSize)
+	space := new(big.Int).Exp(big.NewInt(int64(len(l.chars))), big.NewInt(int64(len(regular))), nil)
+	position := new(big.Rat).SetFrac(new(big.Int).Mul(l.toInt(regular), big.NewInt(100)), space)
+	noun := "blocks"
+	if len(blocks) == 1 {
+		noun = "block"
+	}
+	fmt.Fprintf(&sb, "%d %s [%s], values [%s], padding block: %t, position: %s%%",
+		len(blocks), noun, strings.Join(blocks, " "), strings.Join(values, " "), padding, position.FloatString(2))
+	return sb.String()
+}
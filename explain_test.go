@@ -0,0 +1,25 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Explain(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10)
+	assert.Equal(t, "'a0z000001': 3 blocks [a0z 000 001], values [12995 0 1], padding block: true, position: 27.85%",
+		lid.Explain("a0z000001"))
+	assert.Equal(t, "'i01': 1 block [i01], values [23329], padding block: false, position: 50.00%", lid.Explain("i01"))
+	assert.Equal(t, "'001': 1 block [001], values [1], padding block: false, position: 0.00%", lid.Explain("001"))
+	assert.Equal(t, "'zzz': 1 block [zzz], values [46655], padding block: false, position: 100.00%", lid.Explain("zzz"))
+	assert.Equal(t, "'a00': invalid: invalid id: 'a00' ends with the lower char", lid.Explain("a00"))
+
+	ns := lid.WithNamespace("abc")
+	assert.Equal(t, "'abci01': namespace 'abc', 1 block [i01], values [23329], padding block: false, position: 50.00%",
+		ns.Explain("abci01"))
+
+	sg := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithShortGreater(true))
+	id := sg.toShortGreater("i01")
+	assert.Contains(t, sg.Explain(id), "regular 'i01', 1 block [i01]")
+}
@@ -0,0 +1,44 @@
+package lexid
+
+import "fmt"
+
+// IsSupersetOf reports whether the alphabet contains all chars of the other alphabet in the same relative order,
+// so keys of both Lexids can be mixed in one sorted list
+func (l Lexid) IsSupersetOf(other *Lexid) bool {
+	last := -1
+	for _, c := range other.chars {
+		idx := l.charIndex[c]
+		if idx <= last {
+			return false
+		}
+		last = idx
+	}
+	return true
+}
+
+// Union creates a Lexid over the deduplicated union of both alphabets, see New for the validation.
+// The result is a superset of Lexids over a and b, so the existing keys from either source remain valid and sorted under it:
+// the lower char of the union is never greater than the lower char of a source, so the source keys never end with it.
+func Union(a, b string, blockSize, stepSize int) (*Lexid, error) {
+	return New(a+b, blockSize, stepSize)
+}
+
+// BetweenForeign generates a key strictly between prev and before produced by a foreign generator.
+// The foreign keys may have any length, but must consist of chars of the alphabet,
+// check the foreign alphabet with IsSupersetOf to be sure. Empty prev means the start of the list.
+func (l Lexid) BetweenForeign(prev, before string) (string, error) {
+	if err := l.checkChars(prev); err != nil {
+		return "", err
+	}
+	if err := l.checkChars(before); err != nil {
+		return "", err
+	}
+	next, err := l.NextBefore(prev, before)
+	if err != nil {
+		return "", err
+	}
+	if !l.less(prev, next) || !l.less(next, before) {
+		return "", fmt.Errorf("%w: '%s' and '%s'; result='%s'", ErrNoMidpoint, prev, before, next)
+	}
+	return next, nil
+}
@@ -0,0 +1,65 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_IsSupersetOf(t *testing.T) {
+	lid := Must(CharsAlphanumeric, 3, 10)
+	assert.True(t, lid.IsSupersetOf(Must("0123456789", 1, 1)))
+	assert.True(t, lid.IsSupersetOf(Must(CharsBase58, 4, 1)))
+	assert.True(t, lid.IsSupersetOf(lid))
+	assert.False(t, lid.IsSupersetOf(Must(CharsBase64, 3, 1)))
+	assert.False(t, Must("0123456789", 1, 1).IsSupersetOf(lid))
+	ordered, err := NewOrdered("9876543210", 1, 1)
+	require.NoError(t, err)
+	assert.False(t, lid.IsSupersetOf(ordered))
+}
+
+func TestLexid_BetweenForeign(t *testing.T) {
+	lid := Must(CharsAlphanumeric, 3, 10)
+	digits := Must("0123456789", 2, 3)
+	require.True(t, lid.IsSupersetOf(digits))
+	foreign := digits.GenerateAfter("", 200)
+	for _, tc := range [][2]string{{"", foreign[0]}, {foreign[0], foreign[1]}, {foreign[10], foreign[11]}, {"5", "51"}, {"51", "6"}, {"0099", "01"}} {
+		key, err := lid.BetweenForeign(tc[0], tc[1])
+		require.NoError(t, err, tc)
+		assert.Greater(t, key, tc[0])
+		assert.Less(t, key, tc[1])
+	}
+	t.Run("errors", func(t *testing.T) {
+		_, err := lid.BetweenForeign("01", "0-")
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.BetweenForeign("02", "01")
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+}
+
+func TestUnion(t *testing.T) {
+	u, err := Union("0123456789", "abcdef789", 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", string(u.chars))
+
+	for _, chars := range []string{"0123456789", "abcdef789"} {
+		src := Must(chars, 2, 4)
+		assert.True(t, u.IsSupersetOf(src))
+		ids := src.GenerateAfter("", 100)
+		for _, id := range ids {
+			assert.True(t, u.IsValid(id), id)
+		}
+		assert.True(t, sort.StringsAreSorted(ids))
+		next, err := u.NextBefore(ids[10], ids[11])
+		require.NoError(t, err)
+		assert.Less(t, ids[10], next)
+		assert.Less(t, next, ids[11])
+	}
+
+	_, err = Union("a", "a", 2, 1)
+	assert.ErrorIs(t, err, ErrInvalidChars)
+	_, err = Union("01", "", 1, 2)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
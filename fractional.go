@@ -0,0 +1,249 @@
+package lexid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// NewFractionalCompat creates a Lexid compatible with the JS fractional-indexing library.
+// It uses the base-62 digit set of that library and Between replicates its generateKeyBetween algorithm,
+// so keys generated by either side interleave correctly in the same list.
+// Only keys produced by Between are accepted by fractional-indexing clients.
+func NewFractionalCompat() *Lexid {
+	l := Must(CharsAlphanumeric, 1, 1)
+	l.fractional = true
+	return l
+}
+
+// Between generates an ID between prev and before. An empty prev or before means there is no bound on that side.
+// For generators created by NewFractionalCompat it follows the fractional-indexing algorithm,
+// otherwise it's equivalent to Next (empty before) or NextBefore.
+func (l Lexid) Between(prev, before string) (string, error) {
+	if l.fractional {
+		return l.fractionalBetween(prev, before)
+	}
+	if before == "" {
+		return l.Next(prev), nil
+	}
+	return l.NextBefore(prev, before)
+}
+
+func (l Lexid) fractionalBetween(a, b string) (string, error) {
+	if a != "" {
+		if err := l.fractionalValidate(a); err != nil {
+			return "", err
+		}
+	}
+	if b != "" {
+		if err := l.fractionalValidate(b); err != nil {
+			return "", err
+		}
+	}
+	if a != "" && b != "" && a >= b {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, b, a)
+	}
+	if a == "" {
+		if b == "" {
+			return "a" + string(l.lower), nil
+		}
+		ib, _ := l.fractionalInteger(b)
+		fb := b[len(ib):]
+		if ib == l.fractionalSmallestInteger() {
+			return ib + l.fractionalMidpoint("", fb, false), nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		res, ok := l.fractionalDecrement(ib)
+		if !ok {
+			return "", fmt.Errorf("%w: unable to create id before the smallest integer part", ErrExhausted)
+		}
+		return res, nil
+	}
+	ia, _ := l.fractionalInteger(a)
+	fa := a[len(ia):]
+	if b == "" {
+		if i, ok := l.fractionalIncrement(ia); ok {
+			return i, nil
+		}
+		return ia + l.fractionalMidpoint(fa, "", true), nil
+	}
+	ib, _ := l.fractionalInteger(b)
+	fb := b[len(ib):]
+	if ia == ib {
+		return ia + l.fractionalMidpoint(fa, fb, false), nil
+	}
+	i, ok := l.fractionalIncrement(ia)
+	if !ok {
+		return "", fmt.Errorf("%w: unable to create id after the biggest integer part", ErrExhausted)
+	}
+	if i < b {
+		return i, nil
+	}
+	return ia + l.fractionalMidpoint(fa, "", true), nil
+}
+
+// fractionalMidpoint returns a fraction between a and b; noUpper means b is not bounded
+func (l Lexid) fractionalMidpoint(a, b string, noUpper bool) string {
+	if !noUpper {
+		var n int
+		for n < len(b) && l.fractionalDigit(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			var tailA string
+			if n < len(a) {
+				tailA = a[n:]
+			}
+			return b[:n] + l.fractionalMidpoint(tailA, b[n:], false)
+		}
+	}
+	var digitA, digitB = 0, len(l.chars)
+	if a != "" {
+		digitA = l.charIndex[a[0]]
+	}
+	if !noUpper {
+		digitB = l.charIndex[b[0]]
+	}
+	if digitB-digitA > 1 {
+		return string(l.chars[(digitA+digitB+1)/2])
+	}
+	if !noUpper && len(b) > 1 {
+		return b[:1]
+	}
+	var tailA string
+	if len(a) > 1 {
+		tailA = a[1:]
+	}
+	return string(l.chars[digitA]) + l.fractionalMidpoint(tailA, "", true)
+}
+
+func (l Lexid) fractionalDigit(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return l.lower
+}
+
+func (l Lexid) fractionalIntegerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+func (l Lexid) fractionalInteger(key string) (string, error) {
+	n, ok := l.fractionalIntegerLength(key[0])
+	if !ok {
+		return "", fmt.Errorf("%w: invalid key head: '%s'", ErrInvalidID, key)
+	}
+	if n > len(key) {
+		return "", fmt.Errorf("%w: invalid key length: '%s'", ErrInvalidID, key)
+	}
+	return key[:n], nil
+}
+
+func (l Lexid) fractionalSmallestInteger() string {
+	return "A" + strings.Repeat(string(l.lower), 26)
+}
+
+func (l Lexid) fractionalValidate(key string) error {
+	if key == l.fractionalSmallestInteger() {
+		return fmt.Errorf("%w: '%s'", ErrInvalidID, key)
+	}
+	i, err := l.fractionalInteger(key)
+	if err != nil {
+		return err
+	}
+	for j := 0; j < len(key); j++ {
+		if l.charIndex[key[j]] == -1 {
+			return fmt.Errorf("%w: invalid key char: '%s'", ErrInvalidID, key)
+		}
+	}
+	if len(key) > len(i) && key[len(key)-1] == l.lower {
+		return fmt.Errorf("%w: invalid key with trailing zero: '%s'", ErrInvalidID, key)
+	}
+	return nil
+}
+
+func (l Lexid) fractionalIncrement(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		if digs[i] == l.upper {
+			digs[i] = l.lower
+		} else {
+			digs[i] = l.nextChar[digs[i]]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digs), true
+	}
+	switch head {
+	case 'Z':
+		return "a" + string(l.lower), true
+	case 'z':
+		return "", false
+	}
+	head++
+	if head > 'a' {
+		digs = append(digs, l.lower)
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+func (l Lexid) fractionalDecrement(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	borrow := true
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		if digs[i] == l.lower {
+			digs[i] = l.upper
+		} else {
+			digs[i] = l.chars[l.charIndex[digs[i]]-1]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+	switch head {
+	case 'a':
+		return "Z" + string(l.upper), true
+	case 'A':
+		return "", false
+	}
+	head--
+	if head < 'Z' {
+		digs = append(digs, l.upper)
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+// fractionalSpread returns n successive keys after the empty list like generateNKeysBetween(null, null, n):
+// "a0", "a1" and so on
+func (l Lexid) fractionalSpread(ctx context.Context, n int) ([]string, error) {
+	ids := make([]string, n)
+	var prev string
+	for i := range ids {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		next, err := l.fractionalBetween(prev, "")
+		if err != nil {
+			return nil, err
+		}
+		ids[i], prev = next, next
+	}
+	return ids, nil
+}
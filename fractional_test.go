@@ -0,0 +1,116 @@
+package lexid
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_FractionalBetween(t *testing.T) {
+	lid := NewFractionalCompat()
+	// expected values are produced by generateKeyBetween of the fractional-indexing JS library
+	for _, tc := range []struct {
+		a, b, expected string
+	}{
+		{"", "", "a0"},
+		{"", "a0", "Zz"},
+		{"", "Zz", "Zy"},
+		{"a0", "", "a1"},
+		{"a1", "", "a2"},
+		{"a0", "a1", "a0V"},
+		{"a1", "a2", "a1V"},
+		{"a0V", "a1", "a0l"},
+		{"Zz", "a0", "ZzV"},
+		{"Zz", "a1", "a0"},
+		{"", "Y00", "Xzzz"},
+		{"bzz", "", "c000"},
+		{"a0", "a0V", "a0G"},
+		{"a0", "a0G", "a08"},
+		{"b125", "b129", "b127"},
+		{"a0", "a1V", "a1"},
+		{"Zz", "a01", "a0"},
+		{"", "a0V", "a0"},
+		{"", "b999", "b99"},
+		{"", "A000000000000000000000000001", "A000000000000000000000000000V"},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzy", "", "zzzzzzzzzzzzzzzzzzzzzzzzzzz"},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzz", "", "zzzzzzzzzzzzzzzzzzzzzzzzzzzV"},
+	} {
+		res, err := lid.Between(tc.a, tc.b)
+		require.NoError(t, err, tc)
+		assert.Equal(t, tc.expected, res, tc)
+	}
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, tc := range [][2]string{
+			{"", "A00000000000000000000000000"},
+			{"a00", ""},
+			{"a00", "a1"},
+			{"0", "1"},
+			{"a1", "a0"},
+		} {
+			_, err := lid.Between(tc[0], tc[1])
+			assert.Error(t, err, tc)
+		}
+	})
+	t.Run("mixed clients", func(t *testing.T) {
+		// keys appended by a JS client
+		ids := []string{"a0", "a1", "a2", "a3"}
+		// keys inserted by JS client between a0 and a1, and between a2 and a3
+		jsInserted := []string{"a0V", "a2V"}
+		ids = append(ids, jsInserted...)
+		sort.Strings(ids)
+		var inserted []string
+		for i := 0; i < len(ids)-1; i++ {
+			next, err := lid.Between(ids[i], ids[i+1])
+			require.NoError(t, err)
+			inserted = append(inserted, next)
+		}
+		ids = append(ids, inserted...)
+		sort.Strings(ids)
+		assert.Equal(t, []string{"a0", "a0G", "a0V", "a0l", "a1", "a1V", "a2", "a2G", "a2V", "a2l", "a3"}, ids)
+		for i := 1; i < len(ids); i++ {
+			assert.Greater(t, ids[i], ids[i-1])
+		}
+	})
+	t.Run("not compatible", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		next, err := lid.Between("", "")
+		require.NoError(t, err)
+		assert.Equal(t, lid.Next(""), next)
+		next, err = lid.Between("001", "002")
+		require.NoError(t, err)
+		assert.Greater(t, next, "001")
+		assert.Less(t, next, "002")
+	})
+}
+
+func TestLexid_FractionalHelpers(t *testing.T) {
+	lid := NewFractionalCompat()
+	assert.Equal(t, "a0", lid.Middle())
+	center, err := lid.CenterOf(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "a0", center)
+	key, err := lid.KeyAtRank(nil, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, "a0", key)
+
+	// generateNKeysBetween(null, null, 3)
+	assert.Equal(t, []string{"a0", "a1", "a2"}, lid.Spread(3))
+	ids, err := lid.Rebalance([]string{"B", "V", "p"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a0", "a1", "a2"}, ids)
+	parallel, err := lid.SpreadParallel(context.Background(), 100, 4)
+	require.NoError(t, err)
+	assert.Equal(t, lid.Spread(100), parallel)
+	for _, id := range parallel {
+		require.NoError(t, lid.fractionalValidate(id))
+	}
+
+	for target, expected := range []string{"Zz", "a0V", "a1V", "a3"} {
+		key, err := lid.InsertKey([]string{"a0", "a1", "a2"}, target)
+		require.NoError(t, err)
+		assert.Equal(t, expected, key)
+	}
+}
@@ -0,0 +1,219 @@
+package lexid
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Generator is a stateful cursor producing successive IDs with Next. It's not safe for concurrent use.
+type Generator struct {
+	l      *Lexid
+	cursor string
+}
+
+// NewGenerator returns a Generator producing IDs after start, the empty start begins with the first ID
+func (l *Lexid) NewGenerator(start string) *Generator {
+	return &Generator{l: l, cursor: start}
+}
+
+// Next advances the cursor and returns the new ID
+func (g *Generator) Next() string {
+	g.cursor = g.l.Next(g.cursor)
+	return g.cursor
+}
+
+// Current returns the last generated ID or the start if no IDs were generated
+func (g *Generator) Current() string {
+	return g.cursor
+}
+
+// Reset moves the cursor to start without validation, the empty start begins with the first ID again
+func (g *Generator) Reset(start string) {
+	g.cursor = start
+}
+
+// Seek moves the cursor to the id, so the next ID follows it. It returns an error and keeps the cursor if the id is not valid.
+func (g *Generator) Seek(id string) error {
+	if err := g.l.Validate(id); err != nil {
+		return err
+	}
+	g.cursor = id
+	return nil
+}
+
+// BeforeGenerator is a stateful cursor producing successive IDs between the last generated ID and the fixed before bound,
+// for example to keep adding items to the top of a descending list. It's not safe for concurrent use.
+type BeforeGenerator struct {
+	l      *Lexid
+	cursor string
+	before string
+}
+
+// NewBeforeGenerator returns a BeforeGenerator producing IDs after start and before the bound,
+// the empty start begins from the start of the list
+func (l *Lexid) NewBeforeGenerator(start, before string) *BeforeGenerator {
+	return &BeforeGenerator{l: l, cursor: start, before: before}
+}
+
+// Next returns the ID between the last generated ID and the bound, see NextBefore.
+// The IDs grow when the gap is exhausted at the current length, so the error is returned and the cursor is kept
+// when they exceed the max length, see WithMaxLength, or the bound is not greater than the cursor.
+func (g *BeforeGenerator) Next() (string, error) {
+	next, err := g.l.NextBefore(g.cursor, g.before)
+	if err != nil {
+		return "", err
+	}
+	if !g.l.less(g.cursor, next) || !g.l.less(next, g.before) {
+		return "", fmt.Errorf("%w: '%s' and '%s'; result='%s'", ErrNoMidpoint, g.cursor, g.before, next)
+	}
+	g.cursor = next
+	return next, nil
+}
+
+// Current returns the last generated ID or the start if no IDs were generated
+func (g *BeforeGenerator) Current() string {
+	return g.cursor
+}
+
+// Before returns the bound all generated IDs are less than
+func (g *BeforeGenerator) Before() string {
+	return g.before
+}
+
+// SafeGenerator is a Generator safe for concurrent use, every call of Next returns a unique ID
+type SafeGenerator struct {
+	mu sync.Mutex
+	g  Generator
+}
+
+// NewSafeGenerator returns a SafeGenerator producing IDs after start, the empty start begins with the first ID
+func (l *Lexid) NewSafeGenerator(start string) *SafeGenerator {
+	return &SafeGenerator{g: Generator{l: l, cursor: start}}
+}
+
+// Next advances the cursor and returns the new ID
+func (g *SafeGenerator) Next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.Next()
+}
+
+// Current returns the last generated ID or the start if no IDs were generated
+func (g *SafeGenerator) Current() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.Current()
+}
+
+// Reset moves the cursor to start without validation, see Generator.Reset
+func (g *SafeGenerator) Reset(start string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.Reset(start)
+}
+
+// Seek moves the cursor to the id, see Generator.Seek
+func (g *SafeGenerator) Seek(id string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.Seek(id)
+}
+
+// AtomicGenerator produces successive IDs from an atomic counter without locking, it's safe for concurrent use.
+// Unlike SafeGenerator all IDs have the same width and the generator can't start after an arbitrary ID.
+type AtomicGenerator struct {
+	seq    uint64
+	l      *Lexid
+	digits int
+	length int
+}
+
+// NewAtomicGenerator returns an AtomicGenerator producing IDs for the counter values after seq
+func (l *Lexid) NewAtomicGenerator(seq uint64) *AtomicGenerator {
+	digits := 1
+	base := uint64(len(l.chars))
+	for v := uint64(math.MaxUint64); v >= base; v /= base {
+		digits++
+	}
+	// one more char for the trailing non-lower char, rounded up to whole blocks
+	length := (digits + l.blockSize) / l.blockSize * l.blockSize
+	return &AtomicGenerator{seq: seq, l: l, digits: length - 1, length: length}
+}
+
+// Next increments the counter and returns the ID for the new value
+func (g *AtomicGenerator) Next() string {
+	return g.key(atomic.AddUint64(&g.seq, 1))
+}
+
+// Seq returns the last counter value
+func (g *AtomicGenerator) Seq() uint64 {
+	return atomic.LoadUint64(&g.seq)
+}
+
+// key encodes v in digits chars followed by the char after lower, so the keys are valid and ordered as the values.
+// In the short greater ordering the last char is the char before upper, the mirror of the char after lower,
+// followed by the terminator.
+func (g *AtomicGenerator) key(v uint64) string {
+	l := g.l
+	base := uint64(len(l.chars))
+	length := g.length
+	if l.shortGreater {
+		length++
+	}
+	res := make([]byte, len(l.namespace)+length)
+	copy(res, l.namespace)
+	b := res[len(l.namespace):]
+	for i := g.digits - 1; i >= 0; i-- {
+		b[i] = l.chars[v%base]
+		v /= base
+	}
+	if l.shortGreater {
+		b[g.digits] = l.prevChar[l.upper]
+		b[g.length] = l.terminator()
+	} else {
+		b[g.digits] = l.nextChar[l.lower]
+	}
+	return string(res)
+}
+
+// StressSorted runs producers goroutines taking perProducer IDs each from a shared SafeGenerator
+// and returns all IDs sorted. It returns an error if a producer got IDs out of order or an ID was returned twice.
+func (l Lexid) StressSorted(producers, perProducer int) ([]string, error) {
+	g := l.NewSafeGenerator("")
+	results := make([][]string, producers)
+	var wg sync.WaitGroup
+	for p := range results {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			ids := make([]string, perProducer)
+			for i := range ids {
+				ids[i] = g.Next()
+			}
+			results[p] = ids
+		}(p)
+	}
+	wg.Wait()
+
+	all := make([]string, 0, producers*perProducer)
+	for p, ids := range results {
+		for i := 1; i < len(ids); i++ {
+			if !l.less(ids[i-1], ids[i]) {
+				return nil, fmt.Errorf("%w: producer %d got '%s' after '%s'", ErrNotSorted, p, ids[i], ids[i-1])
+			}
+		}
+		all = append(all, ids...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return l.less(all[i], all[j])
+	})
+	for i := 1; i < len(all); i++ {
+		if all[i-1] == all[i] {
+			return nil, fmt.Errorf("%w: '%s' is returned twice", ErrNotSorted, all[i])
+		}
+	}
+	return all, nil
+}
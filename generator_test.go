@@ -0,0 +1,167 @@
+package lexid
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	g := lid.NewGenerator("")
+	assert.Equal(t, lid.GenerateAfter("", 3), []string{g.Next(), g.Next(), g.Next()})
+	assert.Equal(t, lid.GenerateAfter("", 3)[2], g.Current())
+
+	t.Run("reset", func(t *testing.T) {
+		g.Reset("")
+		assert.Equal(t, "", g.Current())
+		assert.Equal(t, lid.Next(""), g.Next())
+		g.Reset("zzz")
+		assert.Equal(t, lid.Next("zzz"), g.Next())
+	})
+	t.Run("seek", func(t *testing.T) {
+		require.NoError(t, g.Seek("abc"))
+		assert.Equal(t, lid.Next("abc"), g.Next())
+		cur := g.Current()
+		for _, id := range []string{"", "ab", "abA", "ab0"} {
+			assert.ErrorIs(t, g.Seek(id), ErrInvalidID, id)
+		}
+		assert.Equal(t, cur, g.Current())
+	})
+}
+
+func TestBeforeGenerator(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	g := lid.NewBeforeGenerator("", "b00")
+	assert.Equal(t, "b00", g.Before())
+	prev := g.Current()
+	for i := 0; i < 200; i++ {
+		id, err := g.Next()
+		require.NoError(t, err)
+		assert.Less(t, prev, id)
+		assert.Less(t, id, "b00")
+		assert.NoError(t, lid.Validate(id))
+		assert.Equal(t, id, g.Current())
+		prev = id
+	}
+
+	t.Run("exhausted", func(t *testing.T) {
+		short, err := New(CharsAlphanumericLower, 3, 10, WithMaxLength(6))
+		require.NoError(t, err)
+		g := short.NewBeforeGenerator("00a", "00b")
+		var last string
+		for {
+			id, err := g.Next()
+			if err != nil {
+				assert.ErrorIs(t, err, ErrMaxLength)
+				break
+			}
+			last = id
+		}
+		assert.Equal(t, last, g.Current())
+		assert.Len(t, last, 6)
+	})
+	t.Run("bad bound", func(t *testing.T) {
+		g := lid.NewBeforeGenerator("00b", "00b")
+		_, err := g.Next()
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+		assert.Equal(t, "00b", g.Current())
+	})
+}
+
+func TestSafeGenerator(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	g := lid.NewSafeGenerator("")
+	assert.Equal(t, lid.Next(""), g.Next())
+	assert.Equal(t, lid.Next(""), g.Current())
+	require.NoError(t, g.Seek("abc"))
+	assert.Equal(t, lid.Next("abc"), g.Next())
+	assert.ErrorIs(t, g.Seek("ab"), ErrInvalidID)
+	g.Reset("")
+	assert.Equal(t, lid.Next(""), g.Next())
+}
+
+func TestLexid_StressSorted(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 2, 7)
+	ids, err := lid.StressSorted(16, 500)
+	require.NoError(t, err)
+	assert.Len(t, ids, 8000)
+	// the shared cursor produces the same ids as the single threaded generation
+	assert.Equal(t, lid.GenerateAfter("", 8000), ids)
+
+	ordered, err := NewOrdered("zyx0123", 2, 1)
+	require.NoError(t, err)
+	ids, err = ordered.StressSorted(4, 100)
+	require.NoError(t, err)
+	assert.Len(t, ids, 400)
+}
+
+func TestAtomicGenerator(t *testing.T) {
+	for _, lid := range []*Lexid{Must(CharsAlphanumericLower, 3, 10), Must(CharsBase64, 4, 1), Must("0123456789", 1, 1)} {
+		g := lid.NewAtomicGenerator(0)
+		prev := ""
+		for i := 0; i < 100; i++ {
+			id := g.Next()
+			assert.True(t, lid.IsValid(id), id)
+			assert.Equal(t, 0, len(id)%lid.blockSize)
+			assert.Less(t, prev, id)
+			prev = id
+		}
+		assert.Equal(t, uint64(100), g.Seq())
+
+		last := lid.NewAtomicGenerator(math.MaxUint64 - 2)
+		a, b := last.Next(), last.Next()
+		assert.Less(t, prev, a)
+		assert.Less(t, a, b)
+		assert.Len(t, b, len(prev))
+		assert.True(t, lid.IsValid(b), b)
+	}
+}
+
+func TestAtomicGenerator_Concurrent(t *testing.T) {
+	lid := Must(CharsBase64, 4, 1)
+	g := lid.NewAtomicGenerator(0)
+	results := make([][]string, 8)
+	var wg sync.WaitGroup
+	for p := range results {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				results[p] = append(results[p], g.Next())
+			}
+		}(p)
+	}
+	wg.Wait()
+	seen := map[string]bool{}
+	for _, ids := range results {
+		assert.True(t, sort.StringsAreSorted(ids))
+		for _, id := range ids {
+			assert.False(t, seen[id], id)
+			seen[id] = true
+		}
+	}
+	assert.Len(t, seen, 8*500)
+}
+
+func BenchmarkSafeGenerator_Next(b *testing.B) {
+	g := Must(CharsBase64, 4, 1).NewSafeGenerator("")
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Next()
+		}
+	})
+}
+
+func BenchmarkAtomicGenerator_Next(b *testing.B) {
+	g := Must(CharsBase64, 4, 1).NewAtomicGenerator(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Next()
+		}
+	})
+}
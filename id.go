@@ -0,0 +1,39 @@
+package lexid
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// idValidator holds the *Lexid validating ID values decoded from JSON, nil disables the validation
+var idValidator atomic.Value
+
+// SetValidator sets the Lexid used to validate ID values decoded from JSON, nil disables the validation.
+// It's safe to call concurrently with decoding. PBID values have their own validator, see SetPBIDValidator.
+func SetValidator(l *Lexid) {
+	idValidator.Store(l)
+}
+
+// loadValidator returns the Lexid stored in the validator or nil
+func loadValidator(v *atomic.Value) *Lexid {
+	l, _ := v.Load().(*Lexid)
+	return l
+}
+
+// ID is a string ID that is validated when decoded from JSON, see SetValidator
+type ID string
+
+// UnmarshalJSON decodes the ID from a JSON string and validates it
+func (id *ID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if v := loadValidator(&idValidator); v != nil && string(data) != "null" {
+		if err := v.Validate(s); err != nil {
+			return err
+		}
+	}
+	*id = ID(s)
+	return nil
+}
@@ -0,0 +1,79 @@
+package lexid
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	type item struct {
+		ID   ID     `json:"id"`
+		Name string `json:"name"`
+	}
+	type request struct {
+		Items []item `json:"items"`
+		After *ID    `json:"after"`
+	}
+	t.Run("without validator", func(t *testing.T) {
+		var req request
+		require.NoError(t, json.Unmarshal([]byte(`{"items":[{"id":"not an id"}]}`), &req))
+		assert.Equal(t, ID("not an id"), req.Items[0].ID)
+	})
+	t.Run("with validator", func(t *testing.T) {
+		SetValidator(Must(CharsAlphanumericLower, 3, 1))
+		defer SetValidator(nil)
+		var req request
+		require.NoError(t, json.Unmarshal([]byte(`{"items":[{"id":"001"},{"id":"002001"}],"after":null}`), &req))
+		assert.Equal(t, ID("002001"), req.Items[1].ID)
+		assert.Nil(t, req.After)
+		err := json.Unmarshal([]byte(`{"items":[{"id":"001"},{"id":"0010","name":"x"}]}`), &req)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		err = json.Unmarshal([]byte(`{"after":""}`), &req)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		err = json.Unmarshal([]byte(`{"after":1}`), &req)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetValidator(t *testing.T) {
+	t.Run("separate", func(t *testing.T) {
+		SetValidator(Must(CharsAlphanumericLower, 3, 1))
+		defer SetValidator(nil)
+		var id ID
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"0010"`), &id), ErrInvalidID)
+		// PBID values are validated by SetPBIDValidator only
+		var pbid PBID
+		assert.NoError(t, pbid.Unmarshal([]byte("0010")))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		three, two := Must(CharsAlphanumericLower, 3, 1), Must(CharsAlphanumericLower, 2, 1)
+		SetValidator(three)
+		defer SetValidator(nil)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					SetValidator(two)
+					SetValidator(three)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					var id ID
+					// valid for both validators
+					assert.NoError(t, json.Unmarshal([]byte(`"001001"`), &id))
+					// valid for none of them, so a missing validator is never seen
+					assert.ErrorIs(t, json.Unmarshal([]byte(`"00100"`), &id), ErrInvalidID)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,202 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Index returns the digit of the char, its position in the alphabet order, or -1 if the char is not in the alphabet
+func (l Lexid) Index(c byte) int {
+	return l.charIndex[c]
+}
+
+// CharAt returns the char with the digit i, it's the inverse of Index. It returns false if i is out of the alphabet.
+func (l Lexid) CharAt(i int) (byte, bool) {
+	if i < 0 || i >= len(l.chars) {
+		return 0, false
+	}
+	return l.chars[i], true
+}
+
+// ToIndex returns the numeric value of the id, where every char is a digit in base len(chars).
+// Ids of the same length compare the same way as their indexes.
+func (l Lexid) ToIndex(id string) (*big.Int, error) {
+	if err := l.checkChars(id); err != nil {
+		return nil, err
+	}
+	return l.toInt(id), nil
+}
+
+// ToDecimal returns the number written by the id for the digits alphabet "0123456789", for example 120 for "120".
+// It returns an error for other alphabets, use ToIndex for them.
+func (l Lexid) ToDecimal(id string) (*big.Int, error) {
+	if string(l.chars) != "0123456789" {
+		return nil, fmt.Errorf("%w: alphabet '%s' is not decimal digits", ErrInvalidConfig, l.chars)
+	}
+	return l.ToIndex(id)
+}
+
+// FromIndex encodes the index as an id of the given length, it's the inverse of ToIndex.
+// It returns an error if the index is negative or doesn't fit in the length.
+func (l Lexid) FromIndex(idx *big.Int, length int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(int64(len(l.chars))), big.NewInt(int64(length)), nil)
+	if idx.Sign() < 0 || idx.Cmp(limit) >= 0 {
+		return "", fmt.Errorf("%w: index %s doesn't fit in %d chars", ErrInvalidID, idx, length)
+	}
+	return l.fromInt(idx, length), nil
+}
+
+// MidIndex returns the index of the valid id (without the trailing lower char) closest to the middle of a and b
+// and strictly between them, or nil if there is no such index
+func (l Lexid) MidIndex(a, b *big.Int) *big.Int {
+	if l.countBetweenInt(a, b).Sign() == 0 {
+		return nil
+	}
+	return l.midpointInt(a, b)
+}
+
+// toInt returns the numeric value of the id, where every char is a digit in base len(chars)
+func (l Lexid) toInt(id string) *big.Int {
+	base := big.NewInt(int64(len(l.chars)))
+	v := new(big.Int)
+	for i := 0; i < len(id); i++ {
+		v.Mul(v, base)
+		v.Add(v, big.NewInt(int64(l.charIndex[id[i]])))
+	}
+	return v
+}
+
+// toIntLength returns the numeric value of the id right-padded with lower chars up to the given length
+func (l Lexid) toIntLength(id string, length int) *big.Int {
+	v := l.toInt(id)
+	if length > len(id) {
+		base := big.NewInt(int64(len(l.chars)))
+		v.Mul(v, new(big.Int).Exp(base, big.NewInt(int64(length-len(id))), nil))
+	}
+	return v
+}
+
+// fromInt encodes the value as an id of the given length, the value must be less than len(chars)^length
+func (l Lexid) fromInt(v *big.Int, length int) string {
+	base := big.NewInt(int64(len(l.chars)))
+	res := make([]byte, length)
+	v = new(big.Int).Set(v)
+	digit := new(big.Int)
+	for i := length - 1; i >= 0; i-- {
+		v.DivMod(v, base, digit)
+		res[i] = l.chars[digit.Int64()]
+	}
+	return string(res)
+}
+
+// validCount returns the number of valid ids (without trailing lower char unless trailingMin) with the given number of blocks
+func (l Lexid) validCount(blocks int) *big.Int {
+	base := big.NewInt(int64(len(l.chars)))
+	if l.trailingMin {
+		return new(big.Int).Exp(base, big.NewInt(int64(blocks*l.blockSize)), nil)
+	}
+	count := new(big.Int).Exp(base, big.NewInt(int64(blocks*l.blockSize-1)), nil)
+	return count.Mul(count, big.NewInt(int64(len(l.chars)-1)))
+}
+
+// fromValidIndex returns the valid id with the given index among all valid ids of the given number of blocks
+func (l Lexid) fromValidIndex(idx *big.Int, blocks int) string {
+	if l.trailingMin {
+		return l.fromInt(idx, blocks*l.blockSize)
+	}
+	base := big.NewInt(int64(len(l.chars)))
+	q, r := new(big.Int).QuoRem(idx, big.NewInt(int64(len(l.chars)-1)), new(big.Int))
+	q.Mul(q, base)
+	q.Add(q, r)
+	q.Add(q, big.NewInt(1))
+	return l.fromInt(q, blocks*l.blockSize)
+}
+
+// CountBetween returns the number of valid IDs strictly between a and b.
+// IDs are counted at the length of the longer bound rounded up to blockSize, the shorter bound is padded with lower chars.
+func (l Lexid) CountBetween(a, b string) *big.Int {
+	if l.shortGreater {
+		return l.shortGreaterCountBetween(a, b)
+	}
+	length := l.alignedLength(a, b)
+	return l.countBetweenInt(l.toIntLength(a, length), l.toIntLength(b, length))
+}
+
+// shortGreaterCountBetween is CountBetween for the swapped bounds in the regular ordering,
+// empty a is the start of the list, so all IDs of the length after b are counted
+func (l Lexid) shortGreaterCountBetween(a, b string) *big.Int {
+	if b == "" {
+		return new(big.Int)
+	}
+	r := l.regular()
+	lo := l.toRegular(b)
+	if a == "" {
+		length := r.alignedLength(lo, "")
+		limit := new(big.Int).Exp(big.NewInt(int64(len(l.chars))), big.NewInt(int64(length)), nil)
+		return r.countBetweenInt(r.toIntLength(lo, length), limit)
+	}
+	return r.CountBetween(lo, l.toRegular(a))
+}
+
+// Adjacent reports whether no valid ID fits strictly between a and b without growing the length.
+// The length and the padding of the bounds are the same as in CountBetween.
+func (l Lexid) Adjacent(a, b string) bool {
+	return l.CountBetween(a, b).Sign() == 0
+}
+
+// countBetweenInt returns the number of valid values strictly between va and vb
+func (l Lexid) countBetweenInt(va, vb *big.Int) *big.Int {
+	if vb.Cmp(va) <= 0 {
+		return new(big.Int)
+	}
+	base := big.NewInt(int64(len(l.chars)))
+	// all values between a and b except values with the trailing lower char (multiples of base)
+	count := new(big.Int).Sub(vb, va)
+	count.Sub(count, big.NewInt(1))
+	if l.trailingMin {
+		return count
+	}
+	hi := new(big.Int).Sub(vb, big.NewInt(1))
+	hi.Quo(hi, base)
+	lo := new(big.Int).Quo(va, base)
+	return count.Sub(count, hi.Sub(hi, lo))
+}
+
+// midpointInt returns the valid value closest to the middle of va and vb, there must be at least one valid value between them
+func (l Lexid) midpointInt(va, vb *big.Int) *big.Int {
+	m := new(big.Int).Add(va, vb)
+	return l.nearestValidInt(m.Rsh(m, 1), va, vb)
+}
+
+// nearestValidInt moves v strictly between va and vb and then to the closest valid value,
+// there must be at least one valid value between va and vb
+func (l Lexid) nearestValidInt(v, va, vb *big.Int) *big.Int {
+	one := big.NewInt(1)
+	if v.Cmp(va) <= 0 {
+		v.Add(va, one)
+	}
+	if v.Cmp(vb) >= 0 {
+		v.Sub(vb, one)
+	}
+	if !l.trailingMin && new(big.Int).Rem(v, big.NewInt(int64(len(l.chars)))).Sign() == 0 {
+		if v.Add(v, one).Cmp(vb) >= 0 {
+			v.Sub(v, big.NewInt(2))
+		}
+	}
+	return v
+}
+
+// alignedLength returns the length of the longer id rounded up to blockSize and at least the min id length
+func (l Lexid) alignedLength(a, b string) int {
+	length := len(a)
+	if len(b) > length {
+		length = len(b)
+	}
+	if rem := length % l.blockSize; rem != 0 {
+		length += l.blockSize - rem
+	}
+	if length < l.minIDLength() {
+		length = l.minIDLength()
+	}
+	return length
+}
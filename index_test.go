@@ -0,0 +1,133 @@
+package lexid
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Index(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.Equal(t, 0, lid.Index('0'))
+	assert.Equal(t, 10, lid.Index('a'))
+	assert.Equal(t, 35, lid.Index('z'))
+	assert.Equal(t, -1, lid.Index('A'))
+	assert.Equal(t, -1, lid.Index(0))
+	for i := 0; i < 36; i++ {
+		c, ok := lid.CharAt(i)
+		require.True(t, ok)
+		assert.Equal(t, i, lid.Index(c))
+	}
+	for _, i := range []int{-1, 36, 1000} {
+		_, ok := lid.CharAt(i)
+		assert.False(t, ok, i)
+	}
+	c, _ := lid.CharAt(0)
+	assert.Equal(t, lid.PadChar(), c)
+}
+
+func TestLexid_CountBetween(t *testing.T) {
+	lid := Must("0123", 2, 1)
+	assert.Equal(t, int64(0), lid.CountBetween("01", "02").Int64())
+	assert.Equal(t, int64(0), lid.CountBetween("02", "01").Int64())
+	assert.Equal(t, int64(0), lid.CountBetween("01", "01").Int64())
+	// 02, 03
+	assert.Equal(t, int64(2), lid.CountBetween("01", "11").Int64())
+	// 02, 03, 11, 12, 13
+	assert.Equal(t, int64(5), lid.CountBetween("01", "2").Int64())
+	assert.Equal(t, int64(11), lid.CountBetween("", "33").Int64())
+	// 0101..0133 except 0110, 0120, 0130
+	assert.Equal(t, int64(12), lid.CountBetween("01", "0200").Int64())
+
+	all := lid.Spread(int(lid.validCount(2).Int64()))
+	for _, tc := range [][2]string{{"0001", "3333"}, {"1000", "3000"}, {"0101", "0102"}, {"1231", "2113"}} {
+		var count int64
+		for _, id := range all {
+			if id > tc[0] && id < tc[1] {
+				count++
+			}
+		}
+		assert.Equal(t, count, lid.CountBetween(tc[0], tc[1]).Int64(), tc)
+	}
+}
+
+func TestLexid_Adjacent(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.True(t, lid.Adjacent("001", "002"))
+	assert.True(t, lid.Adjacent("00z", "011"))
+	assert.False(t, lid.Adjacent("001", "003"))
+	assert.False(t, lid.Adjacent("00z", "012"))
+	// padded to the longer length: 001001..001zzz fit
+	assert.False(t, lid.Adjacent("001", "002001"))
+	assert.True(t, lid.Adjacent("001zzz", "002"))
+	assert.True(t, lid.Adjacent("002", "001"))
+	// SplitKey keeps the length if there is room
+	for _, tc := range [][2]string{{"001", "002"}, {"001", "003"}, {"00z", "011"}, {"abc", "abe"}} {
+		key, err := lid.SplitKey(tc[0], tc[1])
+		require.NoError(t, err)
+		assert.Equal(t, lid.Adjacent(tc[0], tc[1]), len(key) > 3, tc)
+	}
+}
+
+func TestLexid_MidIndex(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("index", func(t *testing.T) {
+		idx, err := lid.ToIndex("011")
+		require.NoError(t, err)
+		assert.Equal(t, int64(37), idx.Int64())
+		id, err := lid.FromIndex(idx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "011", id)
+		id, err = lid.FromIndex(idx, 6)
+		require.NoError(t, err)
+		assert.Equal(t, "000011", id)
+		_, err = lid.ToIndex("01A")
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.FromIndex(big.NewInt(36*36*36), 3)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.FromIndex(big.NewInt(-1), 3)
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("midpoint", func(t *testing.T) {
+		assert.Equal(t, int64(5), lid.MidIndex(big.NewInt(1), big.NewInt(9)).Int64())
+		// 36 ends with the lower char
+		assert.Equal(t, int64(37), lid.MidIndex(big.NewInt(35), big.NewInt(38)).Int64())
+		assert.Nil(t, lid.MidIndex(big.NewInt(35), big.NewInt(37)))
+		assert.Nil(t, lid.MidIndex(big.NewInt(5), big.NewInt(1)))
+	})
+	t.Run("pipeline", func(t *testing.T) {
+		for _, tc := range [][2]string{{"001", "003"}, {"000", "00z"}, {"abc", "xyz"}, {"00z", "012"}} {
+			a, err := lid.ToIndex(tc[0])
+			require.NoError(t, err)
+			b, err := lid.ToIndex(tc[1])
+			require.NoError(t, err)
+			mid, err := lid.FromIndex(lid.MidIndex(a, b), 3)
+			require.NoError(t, err)
+			key, err := lid.SplitKey(tc[0], tc[1])
+			require.NoError(t, err)
+			assert.Equal(t, key, mid, tc)
+		}
+	})
+}
+
+func TestLexid_ToDecimal(t *testing.T) {
+	lid := Must("9876543210", 3, 1)
+	v, err := lid.ToDecimal("120")
+	require.NoError(t, err)
+	assert.Equal(t, "120", v.String())
+	v, err = lid.ToDecimal("000123456789123456789")
+	require.NoError(t, err)
+	assert.Equal(t, "123456789123456789", v.String())
+	_, err = lid.ToDecimal("12a")
+	assert.ErrorIs(t, err, ErrInvalidID)
+	for _, chars := range []string{"012345678", "0123456789a", CharsAlphanumericLower} {
+		_, err = Must(chars, 3, 1).ToDecimal("120")
+		assert.ErrorIs(t, err, ErrInvalidConfig, chars)
+	}
+	ordered, err := NewOrdered("9876543210", 3, 1)
+	require.NoError(t, err)
+	_, err = ordered.ToDecimal("120")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,44 @@
+package lexid
+
+import "fmt"
+
+// Infer deduces the blockSize of existing IDs from the greatest common divisor of the sample lengths.
+// It returns an error if there are no samples or a sample is not a valid ID of the alphabet with the inferred blockSize.
+// Samples of lengths without a common divisor greater than 1, for example 3 and 4, are inconsistent
+// unless a single char sample confirms blockSize 1.
+func Infer(chars string, samples []string) (blockSize int, err error) {
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("%w: no samples", ErrInvalidID)
+	}
+	shortest := 0
+	for _, s := range samples {
+		blockSize = gcd(blockSize, len(s))
+		if len(s) > 0 && (shortest == 0 || len(s) < shortest) {
+			shortest = len(s)
+		}
+	}
+	if blockSize == 0 {
+		return 0, fmt.Errorf("%w: all samples are empty", ErrInvalidID)
+	}
+	if blockSize == 1 && shortest > 1 {
+		return 0, fmt.Errorf("%w: sample lengths have no common block size", ErrInvalidID)
+	}
+	l, err := New(chars, blockSize, 1)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range samples {
+		if err = l.Validate(s); err != nil {
+			return 0, fmt.Errorf("sample %d doesn't match blockSize %d: %w", i, blockSize, err)
+		}
+	}
+	return blockSize, nil
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
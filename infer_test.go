@@ -0,0 +1,51 @@
+package lexid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfer(t *testing.T) {
+	t.Run("infer", func(t *testing.T) {
+		for _, bs := range []int{1, 2, 3, 5} {
+			lid := Must(CharsAlphanumericLower, bs, 10)
+			samples := lid.GenerateAfter("", 100)
+			// the grown ids have two blocks
+			samples = append(samples, lid.Prev(samples[0]), lid.Next(strings.Repeat("z", bs)))
+			got, err := Infer(CharsAlphanumericLower, samples)
+			require.NoError(t, err)
+			assert.Equal(t, bs, got)
+		}
+	})
+	t.Run("single length", func(t *testing.T) {
+		got, err := Infer(CharsAlphanumericLower, []string{"abcdef", "abcdeg"})
+		require.NoError(t, err)
+		assert.Equal(t, 6, got)
+	})
+	t.Run("single char", func(t *testing.T) {
+		got, err := Infer(CharsAlphanumericLower, []string{"abc", "abcd", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, got)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := Infer(CharsAlphanumericLower, nil)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = Infer(CharsAlphanumericLower, []string{""})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		// the trailing lower char is never produced
+		_, err = Infer(CharsAlphanumericLower, []string{"ab0", "abcabc"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = Infer(CharsAlphanumericLower, []string{"ab", "aB"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		// no common block size
+		_, err = Infer(CharsAlphanumericLower, []string{"abc", "abcd"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = Infer(CharsAlphanumericLower, []string{"ab", "abc", "abcabc"})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = Infer("a", []string{"aa"})
+		assert.ErrorIs(t, err, ErrInvalidChars)
+	})
+}
@@ -0,0 +1,51 @@
+package lexid
+
+import (
+	"fmt"
+	"math"
+)
+
+// InsertKey returns a key for inserting at the target index of the sorted slice, so the key sorts after sorted[target-1]
+// and before sorted[target]. target may be from 0 (before the first ID) to len(sorted) (after the last ID).
+// The slice is not modified.
+func (l Lexid) InsertKey(sorted []string, target int) (key string, err error) {
+	if target < 0 || target > len(sorted) {
+		return "", fmt.Errorf("%w: target %d is out of range [0, %d]", ErrOutOfRange, target, len(sorted))
+	}
+	var prev string
+	if target > 0 {
+		prev = sorted[target-1]
+	}
+	if l.fractional {
+		var before string
+		if target < len(sorted) {
+			before = sorted[target]
+		}
+		return l.Between(prev, before)
+	}
+	if target == len(sorted) {
+		return l.NextErr(prev)
+	}
+	return l.NextBefore(prev, sorted[target])
+}
+
+// KeyAtRank returns a key that lands at the fractional rank of the sorted slice when inserted: 0 is before the first ID,
+// 1 is after the last one and 0.3 is at 30% of the list, at index rank*len(sorted) rounded to the nearest integer.
+// For an empty slice it returns Middle. The slice is not modified.
+func (l Lexid) KeyAtRank(sorted []string, rank float64) (string, error) {
+	if !(rank >= 0 && rank <= 1) {
+		return "", fmt.Errorf("%w: rank %v is out of range [0, 1]", ErrOutOfRange, rank)
+	}
+	if len(sorted) == 0 {
+		return l.Middle(), nil
+	}
+	target := int(math.Round(rank * float64(len(sorted))))
+	var prev, before string
+	if target > 0 {
+		prev = sorted[target-1]
+	}
+	if target < len(sorted) {
+		before = sorted[target]
+	}
+	return l.Between(prev, before)
+}
@@ -0,0 +1,85 @@
+package lexid
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_InsertKey(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("empty", func(t *testing.T) {
+		key, err := lid.InsertKey(nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, lid.Next(""), key)
+	})
+	t.Run("positions", func(t *testing.T) {
+		sorted := lid.GenerateAfter("", 5)
+		orig := append([]string(nil), sorted...)
+		for target := 0; target <= len(sorted); target++ {
+			key, err := lid.InsertKey(sorted, target)
+			require.NoError(t, err)
+			if target > 0 {
+				assert.Greater(t, key, sorted[target-1])
+			}
+			if target < len(sorted) {
+				assert.Less(t, key, sorted[target])
+			}
+		}
+		assert.Equal(t, orig, sorted)
+	})
+	t.Run("repeated inserts", func(t *testing.T) {
+		sorted := lid.GenerateAfter("", 3)
+		for i := 0; i < 300; i++ {
+			target := i % (len(sorted) + 1)
+			key, err := lid.InsertKey(sorted, target)
+			require.NoError(t, err)
+			sorted = append(sorted[:target], append([]string{key}, sorted[target:]...)...)
+		}
+		for i := 1; i < len(sorted); i++ {
+			require.Less(t, sorted[i-1], sorted[i])
+		}
+	})
+	t.Run("out of range", func(t *testing.T) {
+		_, err := lid.InsertKey([]string{"001"}, 2)
+		assert.Error(t, err)
+		_, err = lid.InsertKey([]string{"001"}, -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestLexid_KeyAtRank(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("empty", func(t *testing.T) {
+		key, err := lid.KeyAtRank(nil, 0.3)
+		require.NoError(t, err)
+		assert.Equal(t, lid.Middle(), key)
+	})
+	t.Run("ranks", func(t *testing.T) {
+		sorted := lid.GenerateAfter("", 10)
+		orig := append([]string(nil), sorted...)
+		for _, rank := range []float64{0, 0.3, 0.55, 1} {
+			key, err := lid.KeyAtRank(sorted, rank)
+			require.NoError(t, err)
+			assert.Equal(t, int(math.Round(rank*10)), sort.SearchStrings(sorted, key), rank)
+			assert.NotContains(t, sorted, key)
+		}
+		assert.Equal(t, orig, sorted)
+	})
+	t.Run("fractional", func(t *testing.T) {
+		fc := NewFractionalCompat()
+		sorted := []string{"a0", "a1", "a2"}
+		key, err := fc.KeyAtRank(sorted, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sort.SearchStrings(sorted, key))
+	})
+	t.Run("out of range", func(t *testing.T) {
+		for _, rank := range []float64{-0.1, 1.1, math.NaN()} {
+			_, err := lid.KeyAtRank([]string{"001"}, rank)
+			assert.Error(t, err, rank)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package lexid
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeArray parses a JSON array of strings and checks that every element is a valid ID and the IDs are sorted
+func (l Lexid) DecodeArray(data []byte) ([]string, error) {
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, err
+	}
+	if err := l.checkArray(ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// EncodeArray checks that every element is a valid ID and the IDs are sorted and marshals them as a JSON array
+func (l Lexid) EncodeArray(ids []string) ([]byte, error) {
+	if err := l.checkArray(ids); err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	return json.Marshal(ids)
+}
+
+// checkArray returns an error with the index of the first invalid or unsorted id
+func (l Lexid) checkArray(ids []string) error {
+	for i, id := range ids {
+		if err := l.Validate(id); err != nil {
+			return fmt.Errorf("id at %d: %w", i, err)
+		}
+		if i > 0 && !l.less(ids[i-1], id) {
+			return fmt.Errorf("id at %d: %w: '%s' less or equal '%s'", i, ErrNotSorted, id, ids[i-1])
+		}
+	}
+	return nil
+}
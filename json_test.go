@@ -0,0 +1,48 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_DecodeArray(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("valid", func(t *testing.T) {
+		ids, err := lid.DecodeArray([]byte(`["001","002","002i01","003"]`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"001", "002", "002i01", "003"}, ids)
+		ids, err = lid.DecodeArray([]byte(`[]`))
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := lid.DecodeArray([]byte(`["001","00A"]`))
+		require.ErrorIs(t, err, ErrInvalidID)
+		assert.Contains(t, err.Error(), "id at 1")
+	})
+	t.Run("not sorted", func(t *testing.T) {
+		_, err := lid.DecodeArray([]byte(`["001","003","002"]`))
+		require.ErrorIs(t, err, ErrNotSorted)
+		assert.Contains(t, err.Error(), "id at 2")
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := lid.DecodeArray([]byte(`["001",1]`))
+		assert.Error(t, err)
+	})
+}
+
+func TestLexid_EncodeArray(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	data, err := lid.EncodeArray([]string{"001", "002"})
+	require.NoError(t, err)
+	assert.Equal(t, `["001","002"]`, string(data))
+	data, err = lid.EncodeArray(nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+	_, err = lid.EncodeArray([]string{"002", "001"})
+	assert.ErrorIs(t, err, ErrNotSorted)
+	_, err = lid.EncodeArray([]string{"010"})
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
@@ -0,0 +1,29 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NextLeftPad returns the id following prev by stepSize in the fixed width numeric model:
+// the id is a number in base len(chars) left-padded with lower chars to exactly width chars, like zero-padded integers.
+// Empty prev is zero and shorter prev is padded on the left. Unlike the block model the result may end with the lower char.
+// It returns ErrExhausted if the next value doesn't fit in width chars.
+func (l Lexid) NextLeftPad(prev string, width int) (string, error) {
+	if width < 1 {
+		return "", fmt.Errorf("%w: width must be positive, got %d", ErrInvalidConfig, width)
+	}
+	if len(prev) > width {
+		return "", fmt.Errorf("%w: '%s' is longer than %d", ErrInvalidID, prev, width)
+	}
+	if err := l.checkChars(prev); err != nil {
+		return "", err
+	}
+	v := l.toInt(prev)
+	v.Add(v, big.NewInt(int64(l.stepSize)))
+	limit := new(big.Int).Exp(big.NewInt(int64(len(l.chars))), big.NewInt(int64(width)), nil)
+	if v.Cmp(limit) >= 0 {
+		return "", fmt.Errorf("%w: unable to create id after '%s' within %d chars", ErrExhausted, prev, width)
+	}
+	return l.fromInt(v, width), nil
+}
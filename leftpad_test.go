@@ -0,0 +1,44 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_NextLeftPad(t *testing.T) {
+	lid := Must("0123456789", 3, 1)
+	t.Run("zero padded", func(t *testing.T) {
+		next, err := lid.NextLeftPad("", 4)
+		require.NoError(t, err)
+		assert.Equal(t, "0001", next)
+		next, err = lid.NextLeftPad("9", 4)
+		require.NoError(t, err)
+		assert.Equal(t, "0010", next)
+		next, err = lid.NextLeftPad("0999", 4)
+		require.NoError(t, err)
+		assert.Equal(t, "1000", next)
+	})
+	t.Run("sorted", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 7)
+		var prev string
+		for i := 0; i < 1000; i++ {
+			next, err := lid.NextLeftPad(prev, 3)
+			require.NoError(t, err)
+			assert.Len(t, next, 3)
+			assert.Greater(t, next, prev)
+			prev = next
+		}
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := lid.NextLeftPad("9999", 4)
+		assert.ErrorIs(t, err, ErrExhausted)
+		_, err = lid.NextLeftPad("00001", 4)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.NextLeftPad("a", 4)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.NextLeftPad("", 0)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
@@ -1,8 +1,9 @@
 package lexid
 
 import (
-	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"sort"
 	"strings"
 )
@@ -25,19 +26,26 @@ const (
 
 	// CharsBase58 contains the Base58 character set (no 0, O, I, l)
 	CharsBase58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// CharsUnambiguous contains alphanumeric characters except look-alikes 0, O, 1, l and I, sorted by bytes
+	CharsUnambiguous = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
 )
 
 // Must creates a Lexid and panics if there is an error
-func Must(chars string, blockSize, stepSize int) *Lexid {
-	lexid, err := New(chars, blockSize, stepSize)
+func Must(chars string, blockSize, stepSize int, opts ...Option) *Lexid {
+	lexid, err := New(chars, blockSize, stepSize, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return lexid
 }
 
-// New creates a Lexid and returns an error if blockSize is 0 or invalid chars
-func New(chars string, blockSize, stepSize int) (*Lexid, error) {
+// New creates a Lexid and returns an error if blockSize is 0 or invalid chars or stepSize is not less than the block capacity
+func New(chars string, blockSize, stepSize int, opts ...Option) (*Lexid, error) {
+	return newLexid(chars, blockSize, stepSize, true, opts...)
+}
+
+func newLexid(chars string, blockSize, stepSize int, sorted bool, opts ...Option) (*Lexid, error) {
 	if blockSize < 1 {
 		blockSize = 1
 	}
@@ -56,21 +64,33 @@ func New(chars string, blockSize, stepSize int) (*Lexid, error) {
 	}
 
 	if len(uniqueChars) < 2 {
-		return nil, errors.New("chars must contain at least two unique characters")
+		return nil, fmt.Errorf("%w: chars must contain at least two unique characters", ErrInvalidChars)
+	}
+
+	// the capacity may not fit in int for big blocks
+	capacity := new(big.Int).Exp(big.NewInt(int64(len(uniqueChars))), big.NewInt(int64(blockSize)), nil)
+	if big.NewInt(int64(stepSize)).Cmp(capacity) >= 0 {
+		maxStep := new(big.Int).Sub(capacity, big.NewInt(1))
+		return nil, fmt.Errorf("%w: stepSize (%d) must be less than block capacity (%s); max valid stepSize is %s",
+			ErrInvalidConfig, stepSize, abbreviateInt(capacity), abbreviateInt(maxStep))
 	}
 
-	sort.Slice(uniqueChars, func(i, j int) bool {
-		return uniqueChars[i] < uniqueChars[j]
-	})
+	if sorted {
+		sort.Slice(uniqueChars, func(i, j int) bool {
+			return uniqueChars[i] < uniqueChars[j]
+		})
+	}
 
 	lower := uniqueChars[0]
 	upper := uniqueChars[len(uniqueChars)-1]
 
-	// Initialize the nextChar and charIndex arrays
+	// Initialize the nextChar, prevChar and charIndex arrays
 	nextChar := [256]byte{}
+	prevChar := [256]byte{}
 	charIndex := [256]int{}
 	for i := range nextChar {
 		nextChar[i] = lower
+		prevChar[i] = upper
 		charIndex[i] = -1
 	}
 	for i, c := range uniqueChars {
@@ -79,29 +99,121 @@ func New(chars string, blockSize, stepSize int) (*Lexid, error) {
 		} else {
 			nextChar[c] = uniqueChars[0]
 		}
+		if i > 0 {
+			prevChar[c] = uniqueChars[i-1]
+		} else {
+			prevChar[c] = uniqueChars[len(uniqueChars)-1]
+		}
 		charIndex[c] = i
 	}
 
-	return &Lexid{
+	l := &Lexid{
 		chars:     uniqueChars,
 		blockSize: blockSize,
 		stepSize:  stepSize,
 		lower:     lower,
 		upper:     upper,
 		nextChar:  nextChar,
+		prevChar:  prevChar,
 		charIndex: charIndex,
-	}, nil
+		// the middle char for Middle and tails, see WithMiddleIndex
+		middleIndex: len(uniqueChars) / 2,
+	}
+	if !sorted {
+		l.initEncoding()
+	}
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// NewStrict creates a Lexid like New, but returns an error for blockSize or stepSize less than 1 instead of using 1
+// and for duplicated chars instead of removing them
+func NewStrict(chars string, blockSize, stepSize int, opts ...Option) (*Lexid, error) {
+	if blockSize < 1 {
+		return nil, fmt.Errorf("%w: blockSize must be at least 1, got %d", ErrInvalidConfig, blockSize)
+	}
+	if stepSize < 1 {
+		return nil, fmt.Errorf("%w: stepSize must be at least 1, got %d", ErrInvalidConfig, stepSize)
+	}
+	var seen [256]bool
+	for i := 0; i < len(chars); i++ {
+		if seen[chars[i]] {
+			return nil, fmt.Errorf("%w: char '%c' is repeated at %d", ErrInvalidChars, chars[i], i)
+		}
+		seen[chars[i]] = true
+	}
+	return New(chars, blockSize, stepSize, opts...)
+}
+
+// MaxStep returns the maximum valid stepSize for the given chars and blockSize, capped at math.MaxInt
+func MaxStep(chars string, blockSize int) int {
+	if blockSize < 1 {
+		blockSize = 1
+	}
+	return maxStep(uniqueCharsCount(chars), blockSize)
+}
+
+// maxStep returns the maximum valid stepSize for the number of unique chars and blockSize, capped at math.MaxInt
+func maxStep(charsCount, blockSize int) int {
+	if charsCount < 2 {
+		return 0
+	}
+	capacity := blockCapacity(charsCount, blockSize)
+	if capacity == math.MaxInt {
+		return capacity
+	}
+	return capacity - 1
+}
+
+// abbreviateInt formats the number, long numbers are shortened to the first and the last digits
+func abbreviateInt(v *big.Int) string {
+	s := v.String()
+	if len(s) <= 20 {
+		return s
+	}
+	return fmt.Sprintf("%s...%s (%d digits)", s[:6], s[len(s)-6:], len(s))
+}
+
+// blockCapacity returns the number of different blocks (charsCount^blockSize), capped at math.MaxInt
+func blockCapacity(charsCount, blockSize int) int {
+	capacity := 1
+	for i := 0; i < blockSize; i++ {
+		if capacity > math.MaxInt/charsCount {
+			return math.MaxInt
+		}
+		capacity *= charsCount
+	}
+	return capacity
 }
 
 // Lexid represents a lexicographically sorted ID generator
 type Lexid struct {
-	chars     []byte
-	nextChar  [256]byte
-	charIndex [256]int
-	blockSize int
-	stepSize  int
-	lower     byte
-	upper     byte
+	chars        []byte
+	nextChar     [256]byte
+	prevChar     [256]byte
+	charIndex    [256]int
+	blockSize    int
+	stepSize     int
+	lower        byte
+	upper        byte
+	maxLength    int
+	minLength    int
+	ordered      bool
+	encodeChar   [256]byte
+	decodeChar   [256]byte
+	namespace    string
+	fractional   bool
+	shortGreater bool
+	displaySep   byte
+	strictPrev   bool
+	middleIndex  int
+	trailingMin  bool
+	emptyStart   EmptyStart
+	caseFold     bool
 }
 
 // Next generates the next lexicographically sorted string ID
@@ -109,74 +221,439 @@ func (l Lexid) Next(prev string) (next string) {
 	return l.nextStep(prev, l.stepSize)
 }
 
+// NextOne generates the closest next ID, stepping by 1 regardless of stepSize
+func (l Lexid) NextOne(prev string) string {
+	return l.nextStep(prev, 1)
+}
+
+// NextStep generates the next ID like Next, but advances by step instead of stepSize, without creating a new Lexid.
+// It panics if step is less than 1 or not less than the block capacity, like stepSize in New.
+func (l Lexid) NextStep(prev string, step int) string {
+	l.mustStep(step)
+	return l.nextStep(prev, step)
+}
+
+// mustStep panics if the step is not a valid stepSize for the alphabet and blockSize
+func (l Lexid) mustStep(step int) {
+	if limit := maxStep(len(l.chars), l.blockSize); step < 1 || step > limit {
+		panic(fmt.Errorf("%w: step (%d) must be from 1 to %d", ErrInvalidConfig, step, limit))
+	}
+}
+
+// Advance steps n times forward from the id for positive n or -n times backward for negative n
+func (l Lexid) Advance(id string, n int) string {
+	switch {
+	case n > 0:
+		return l.nextStep(id, n)
+	case n < 0:
+		return l.prevStep(id, -n)
+	}
+	return id
+}
+
+// NextErr generates the next ID like Next and returns an error if the ID exceeds the max length
+func (l Lexid) NextErr(prev string) (string, error) {
+	next := l.nextStep(prev, l.stepSize)
+	if err := l.checkMaxLength(next); err != nil {
+		return "", err
+	}
+	return next, nil
+}
+
+// NextFixed generates the next ID of the same length as prev and returns an error if the ID would need to grow.
+// The length of prev not aligned to blockSize is rounded up, for example "c01" follows "c" for blockSize 3.
+func (l Lexid) NextFixed(prev string) (string, error) {
+	next := l.nextStep(prev, l.stepSize)
+	if prev != "" && len(next) != l.alignedLength(prev, "") {
+		return "", fmt.Errorf("%w: unable to create id after '%s' without growing the length", ErrExhausted, prev)
+	}
+	return next, nil
+}
+
+// NextInfo generates the next ID like Next and reports whether a new block was appended because the current length was exhausted
+func (l Lexid) NextInfo(prev string) (next string, grew bool) {
+	return l.nextStepInfo(prev, l.stepSize)
+}
+
 func (l Lexid) nextStep(prev string, step int) (next string) {
+	next, _ = l.nextStepInfo(prev, step)
+	return next
+}
+
+func (l Lexid) nextStepInfo(prev string, step int) (next string, grew bool) {
+	if l.namespace != "" {
+		next, grew = l.withoutNamespace().nextStepInfo(strings.TrimPrefix(prev, l.namespace), step)
+		return l.namespace + next, grew
+	}
+	if l.shortGreater {
+		// the order is reversed, so the next id is the transformed previous id of the regular ordering
+		regular := l
+		regular.shortGreater = false
+		next, grew = regular.prevStepInfo(l.fromShortGreater(prev), step)
+		return l.toShortGreater(next), grew
+	}
 	if prev == "" {
-		firstId := make([]byte, l.blockSize)
-		for i := range firstId {
-			if i == l.blockSize-1 {
-				firstId[i] = l.nextChar[l.lower]
-			} else {
-				firstId[i] = l.lower
-			}
-		}
-		prev = string(firstId)
+		prev = l.emptyOrigin()
 	}
 
-	if pad := l.blockSize - (len(prev) % l.blockSize); pad != l.blockSize {
-		return l.padding(prev, pad)
+	if len(prev) < l.minLength {
+		prev = l.padMinLength(prev)
+	} else if pad := l.blockSize - (len(prev) % l.blockSize); pad != l.blockSize {
+		return l.padding(prev, pad), false
+	}
+	if l.blockSize == 1 {
+		// fast path: the last char can be advanced without a carry
+		if idx := l.charIndex[prev[len(prev)-1]]; idx != -1 && idx+step < len(l.chars) {
+			return prev[:len(prev)-1] + string(l.chars[idx+step]), false
+		}
 	}
 
 	prevBytes := []byte(prev)
+	for !l.stepUp(prevBytes, step) {
+		grew = true
+		prev = l.padding(prev, l.blockSize)
+		prevBytes = []byte(prev)
+	}
+	return string(prevBytes), grew
+}
 
-doSteps:
-	var carry int
+// NextBytes generates the next ID like Next, but takes and returns byte slices. The result is a newly allocated slice.
+func (l Lexid) NextBytes(prev []byte) []byte {
+	if l.namespace != "" || l.shortGreater {
+		return []byte(l.nextStep(string(prev), l.stepSize))
+	}
+	if len(prev) == 0 {
+		prev = []byte(l.emptyOrigin())
+	}
+	start := func(next []byte) []byte {
+		next = append(next[:0], prev...)
+		for len(next) < l.minLength {
+			next = append(next, l.lower)
+		}
+		return next
+	}
+	next := start(make([]byte, 0, len(prev)+l.minLength+l.blockSize))
+	if pad := l.blockSize - (len(next) % l.blockSize); pad != l.blockSize {
+		return l.appendPadding(next, pad)
+	}
+	// like nextStepInfo, grow by padding blocks until one of the lengths can absorb the step
+	for blocks := 1; !l.stepUp(next, l.stepSize); blocks++ {
+		next = start(next)
+		for i := 0; i < blocks; i++ {
+			next = l.appendPadding(next, l.blockSize)
+		}
+	}
+	return next
+}
+
+// stepUp increments the id in place step times skipping values with the trailing lower char unless they are allowed.
+// It returns false if the id overflows its length, the id is garbage in that case.
+func (l Lexid) stepUp(id []byte, step int) bool {
 	for s := 0; s < step; s++ {
-		carry = 1
-		for i := len(prevBytes) - 1; i >= 0; i-- {
-			if carry == 0 {
-				break
-			}
-			newValue := l.nextChar[prevBytes[i]]
+		carry := true
+		for i := len(id) - 1; i >= 0 && carry; i-- {
+			newValue := l.nextChar[id[i]]
 			if newValue == l.lower {
-				if i == len(prevBytes)-1 {
+				if i == len(id)-1 && !l.trailingMin {
 					newValue = l.nextChar[l.lower]
 				}
-				carry = 1
 			} else {
-				carry = 0
+				carry = false
 			}
-			prevBytes[i] = newValue
+			id[i] = newValue
 		}
-		if carry != 0 {
-			break
+		if carry {
+			return false
 		}
 	}
-	if carry == 1 {
-		prev = l.padding(prev, l.blockSize)
-		prevBytes = []byte(prev)
-		goto doSteps
+	return true
+}
+
+// Prev generates the previous lexicographically sorted string ID
+func (l Lexid) Prev(next string) (prev string) {
+	return l.prevStep(next, l.stepSize)
+}
+
+// Neighbors returns both Prev and Next of the id, so prev < id < next
+func (l Lexid) Neighbors(id string) (prev, next string) {
+	return l.prevStep(id, l.stepSize), l.nextStep(id, l.stepSize)
+}
+
+// PrevOne generates the closest previous ID, stepping by 1 regardless of stepSize
+func (l Lexid) PrevOne(next string) string {
+	return l.prevStep(next, 1)
+}
+
+// PrevStep generates the previous ID like Prev, but steps back by step instead of stepSize, without creating a new Lexid.
+// It panics if step is less than 1 or not less than the block capacity, like stepSize in New.
+func (l Lexid) PrevStep(next string, step int) string {
+	l.mustStep(step)
+	return l.prevStep(next, step)
+}
+
+// PrevErr generates the previous ID like Prev and returns an error if the ID exceeds the max length
+// or, with WithStrictPrev, if the ID would grow by a padding block
+func (l Lexid) PrevErr(next string) (string, error) {
+	prev, grew := l.prevStepInfo(next, l.stepSize)
+	if prev == "" {
+		return "", fmt.Errorf("%w: no id sorts before '%s'", ErrExhausted, next)
+	}
+	if grew && l.strictPrev {
+		return "", fmt.Errorf("%w: unable to create id before '%s' without growing the length; rebalance is needed", ErrExhausted, next)
+	}
+	if err := l.checkMaxLength(prev); err != nil {
+		return "", err
+	}
+	return prev, nil
+}
+
+// CanPrev reports whether stepping back steps times from next keeps the length without appending a padding block
+func (l Lexid) CanPrev(next string, steps int) bool {
+	if next == "" || len(next)%l.blockSize != 0 {
+		return false
+	}
+	_, grew := l.prevStepInfo(next, steps)
+	return !grew
+}
+
+// PrependBudget returns how many times Prev can be called starting from the id before the result grows by a block.
+// The budget is capped at math.MaxInt.
+func (l Lexid) PrependBudget(id string) int {
+	if l.namespace != "" {
+		return l.withoutNamespace().PrependBudget(strings.TrimPrefix(id, l.namespace))
+	}
+	var count *big.Int
+	if l.shortGreater {
+		// Prev steps forward in the regular ordering, so count the valid values above the id up to the max of its length
+		regular := l.fromShortGreater(id)
+		length := l.alignedLength(regular, "")
+		limit := new(big.Int).Exp(big.NewInt(int64(len(l.chars))), big.NewInt(int64(length)), nil)
+		count = l.countBetweenInt(l.toIntLength(regular, length), limit)
+	} else {
+		if id == "" {
+			id = l.emptyOrigin()
+		}
+		// Prev pads the id with lower chars and steps down over the valid values of the same length
+		count = l.countBetweenInt(new(big.Int), l.toIntLength(id, l.alignedLength(id, "")))
+	}
+	count.Quo(count, big.NewInt(int64(l.stepSize)))
+	if !count.IsInt64() || count.Int64() > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(count.Int64())
+}
+
+// PrevOrMin generates the previous ID like Prev, but if Prev would grow the length by the padding block,
+// it returns the min ID of the id length instead and true. For example PrevOrMin("003") is "001", true for stepSize 10.
+func (l Lexid) PrevOrMin(id string) (string, bool) {
+	prev, grew := l.prevStepInfo(id, l.stepSize)
+	if !grew {
+		return prev, false
+	}
+	return l.minID(id), true
+}
+
+// PrevBounded generates the previous ID like Prev, but keeps it strictly greater than the floor, for example a reserved sentinel.
+// If Prev is not greater than the floor it returns the ID between the floor and next, see NextBefore, and true.
+// It returns "", true if next is not greater than the floor. The empty floor is no bound.
+func (l Lexid) PrevBounded(next, floor string) (string, bool) {
+	prev := l.Prev(next)
+	if prev != "" && (floor == "" || l.less(floor, prev)) {
+		return prev, false
+	}
+	between, err := l.NextBefore(floor, next)
+	if err != nil {
+		return "", true
+	}
+	return between, true
+}
+
+// minID returns the least valid ID of the aligned length of the id
+func (l Lexid) minID(id string) string {
+	if l.namespace != "" {
+		return l.namespace + l.withoutNamespace().minID(strings.TrimPrefix(id, l.namespace))
+	}
+	if l.shortGreater {
+		// the least id in the short greater ordering is the transformed greatest regular id
+		length := l.alignedLength(l.fromShortGreater(id), "")
+		return l.toShortGreater(strings.Repeat(string(l.upper), length))
 	}
+	return l.padding("", l.alignedLength(id, ""))
+}
+
+func (l Lexid) prevStep(next string, step int) (prev string) {
+	prev, _ = l.prevStepInfo(next, step)
+	return prev
+}
 
-	return string(prevBytes)
+func (l Lexid) prevStepInfo(next string, step int) (prev string, grew bool) {
+	if l.namespace != "" {
+		prev, grew = l.withoutNamespace().prevStepInfo(strings.TrimPrefix(next, l.namespace), step)
+		return l.namespace + prev, grew
+	}
+	if l.shortGreater {
+		regular := l
+		regular.shortGreater = false
+		prev, grew = regular.nextStepInfo(l.fromShortGreater(next), step)
+		return l.toShortGreater(prev), grew
+	}
+	if next == "" {
+		next = l.emptyOrigin()
+	}
+	if l.blockSize == 1 && len(next) >= l.minLength {
+		// fast path: the last char can be decreased without a borrow and without becoming lower
+		if idx := l.charIndex[next[len(next)-1]]; idx > step {
+			return next[:len(next)-1] + string(l.chars[idx-step]), false
+		}
+	}
+
+	nextBytes := []byte(next)
+	// pad with lower chars to keep the value and to be in blockSize
+	for len(nextBytes)%l.blockSize != 0 || len(nextBytes) < l.minLength {
+		nextBytes = append(nextBytes, l.lower)
+	}
+	if l.trailingMin {
+		return l.prevTrailingMin(nextBytes, step)
+	}
+
+	for s := 0; s < step; s++ {
+		// decrement until the last char is not lower
+		for {
+			borrow := true
+			for i := len(nextBytes) - 1; i >= 0 && borrow; i-- {
+				if nextBytes[i] == l.lower {
+					nextBytes[i] = l.upper
+				} else {
+					nextBytes[i] = l.prevChar[nextBytes[i]]
+					borrow = false
+				}
+			}
+			if borrow {
+				// underflow: the min value of the current length followed by the max block
+				grew = true
+				for i := range nextBytes {
+					nextBytes[i] = l.lower
+				}
+				for i := 0; i < l.blockSize; i++ {
+					nextBytes = append(nextBytes, l.upper)
+				}
+				break
+			}
+			if nextBytes[len(nextBytes)-1] != l.lower {
+				break
+			}
+		}
+	}
+	return string(nextBytes), grew
+}
+
+// prevTrailingMin steps the padded id down over all values of its length, see WithAllowTrailingMin.
+// The id of lower chars only is the least one of any length, so the steps stop at it and the empty string is returned for it.
+func (l Lexid) prevTrailingMin(nextBytes []byte, step int) (prev string, grew bool) {
+	for s := 0; s < step; s++ {
+		borrow := true
+		for i := len(nextBytes) - 1; i >= 0 && borrow; i-- {
+			if nextBytes[i] == l.lower {
+				nextBytes[i] = l.upper
+			} else {
+				nextBytes[i] = l.prevChar[nextBytes[i]]
+				borrow = false
+			}
+		}
+		if borrow {
+			if s == 0 {
+				return "", false
+			}
+			for i := range nextBytes {
+				nextBytes[i] = l.lower
+			}
+			break
+		}
+	}
+	return string(nextBytes), false
 }
 
 func (l Lexid) padding(s string, pad int) string {
-	var strBytes = []byte(s)
+	return string(l.appendPadding([]byte(s), pad))
+}
+
+// appendPadding appends pad-1 lower chars and the char following lower
+func (l Lexid) appendPadding(b []byte, pad int) []byte {
 	for i := 0; i < pad; i++ {
 		if i == pad-1 {
-			strBytes = append(strBytes, l.nextChar[l.lower])
+			b = append(b, l.nextChar[l.lower])
 		} else {
-			strBytes = append(strBytes, l.lower)
+			b = append(b, l.lower)
 		}
 	}
-	return string(strBytes)
+	return b
 }
 
-// NextBefore generates the next lexicographically sorted string ID that is lexicographically less than "before"
+// NextBefore generates the next lexicographically sorted string ID that is lexicographically less than "before".
+// If before is prev followed by a tail leaving no ID of its length between them, for example "zzz" and "zzz001",
+// the result is the midpoint of the IDs one block longer than before, see MidpointBefore.
 func (l Lexid) NextBefore(prev, before string) (string, error) {
-	if before <= prev {
-		return "", fmt.Errorf("incorrect before value: '%s' less or equal '%s'", before, prev)
+	return l.nextBeforeTrace(prev, before, nil)
+}
+
+// NextBeforeTrace generates the ID like NextBefore and returns the trace of the decisions made
+func (l Lexid) NextBeforeTrace(prev, before string) (string, Trace, error) {
+	var trace Trace
+	next, err := l.nextBeforeTrace(prev, before, &trace)
+	return next, trace, err
+}
+
+// Trace describes how NextBefore created the ID
+type Trace struct {
+	// Prefix is true if prev is empty or a prefix of before
+	Prefix bool
+	// MinTail is true if before is prev followed by the min tail, so prev was padded to the length of before or longer
+	MinTail bool
+	// Minimal is true if the step result was longer than needed and the shortest ID between the bounds was used instead
+	Minimal bool
+	// Dist is the approximate distance between the padded bounds
+	Dist int
+	// Step is the step scaled down to the distance, 0 if the step was not used
+	Step int
+	// AddTail is true if the ID was created by appending the middle block to padded prev instead of stepping
+	AddTail bool
+	// Tight is true if before is prev followed by a tail leaving no ID of the length of before between them,
+	// so the shortest ID between the bounds was used without the step heuristics
+	Tight bool
+}
+
+// nextBeforeTrace is NextBefore filling the trace if it's not nil
+func (l Lexid) nextBeforeTrace(prev, before string, trace *Trace) (string, error) {
+	next, err := l.nextBefore(prev, before, trace)
+	if err != nil {
+		return "", err
+	}
+	if err = l.checkMaxLength(next); err != nil {
+		return "", err
+	}
+	return next, nil
+}
+
+func (l Lexid) nextBefore(prev, before string, trace *Trace) (string, error) {
+	if !l.less(prev, before) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	if l.namespace != "" {
+		return l.namespaceNextBefore(prev, before, trace)
 	}
+	if l.shortGreater {
+		// the order is reversed, so the bounds are swapped in the regular ordering
+		regular := l
+		regular.shortGreater = false
+		rPrev, rBefore := l.regularBounds(prev, before)
+		next, err := regular.nextBefore(rPrev, rBefore, trace)
+		if err != nil {
+			return "", err
+		}
+		return l.toShortGreater(next), nil
+	}
+	// ids between prev and the padded prev are shorter than the min length
+	prev = l.padMinLength(prev)
 
 	var prevPad, beforePad = prev, before
 	// make paddings to be sure we're in blockSize
@@ -187,9 +664,45 @@ func (l Lexid) NextBefore(prev, before string) (string, error) {
 		beforePad = l.padding(beforePad, pad)
 	}
 	if prev == "" || strings.HasPrefix(before, prev) {
+		if l.tightTail(prev, before, len(beforePad)) {
+			// only longer ids fit, so grow by exactly one block (or less if a shorter id fits) at the midpoint,
+			// it keeps room on both sides for the following prepends
+			if trace != nil {
+				tail := before[len(prev):]
+				trace.Prefix, trace.Tight, trace.MinTail = true, true, tail == l.padding("", len(tail))
+			}
+			return l.MidpointBefore(prev, before)
+		}
+		// the padding below may produce ids longer than needed, so prefer the shortest one
+		next, err := l.nextBeforeStep(prev, before, prevPad, beforePad, true, trace)
+		if minimal, mErr := l.MidpointBefore(prev, before); mErr == nil && (err != nil || len(minimal) < len(next)) {
+			if trace != nil {
+				trace.Minimal = true
+			}
+			return minimal, nil
+		}
+		return next, err
+	}
+	return l.nextBeforeStep(prev, before, prevPad, beforePad, false, trace)
+}
+
+// tightTail reports whether no ID of the length fits between prev and before, where before starts with prev
+func (l Lexid) tightTail(prev, before string, length int) bool {
+	va, vb := l.boundsAtLength(prev, before, length)
+	return l.countBetweenInt(va, vb).Sign() == 0
+}
+
+func (l Lexid) nextBeforeStep(prev, before, prevPad, beforePad string, prefix bool, trace *Trace) (string, error) {
+	if trace != nil {
+		trace.Prefix = prefix
+	}
+	if prefix {
 		beforeTail := before[len(prev):]
 		// if the beforeTail is the min possible value - increase the prev padding
 		if beforeTail == l.padding("", len(beforeTail)) {
+			if trace != nil {
+				trace.MinTail = true
+			}
 			pad := l.blockSize * (len(beforePad) / l.blockSize)
 			prevPad = l.padding(prevPad, pad)
 			if prevPad == beforePad {
@@ -206,6 +719,9 @@ func (l Lexid) NextBefore(prev, before string) (string, error) {
 	}
 
 	dist := l.approxDistance(prevPad, beforePad)
+	if trace != nil {
+		trace.Dist = dist
+	}
 	if dist > 0 {
 		step := l.stepSize
 		for float64(step)/float64(dist) > 0.3 {
@@ -213,18 +729,133 @@ func (l Lexid) NextBefore(prev, before string) (string, error) {
 		}
 		if step > 0 {
 			next := l.nextStep(prevPad, step)
-			if next < before {
+			if l.less(next, before) {
+				if trace != nil {
+					trace.Step = step
+				}
 				return next, nil
 			}
 		}
 	}
+	if trace != nil {
+		trace.AddTail = true
+	}
 	next := l.addTail(prevPad)
-	if prev > next || next > before {
-		return "", fmt.Errorf("unable to create id between '%s' and '%s'; result='%s'", prev, before, next)
+	if l.less(next, prev) || l.less(before, next) {
+		return "", fmt.Errorf("%w: '%s' and '%s'; result='%s'", ErrNoMidpoint, prev, before, next)
 	}
 	return next, nil
 }
 
+// NextBeforeN generates k increasing IDs between prev and before in one pass.
+// IDs are evenly spaced in the gap, the length grows by blocks only if the gap can't fit k IDs.
+func (l Lexid) NextBeforeN(prev, before string, k int) ([]string, error) {
+	if !l.less(prev, before) {
+		return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+	if l.shortGreater {
+		return l.shortGreaterNextBeforeN(prev, before, k)
+	}
+	if l.namespace != "" {
+		if _, err := l.trimNamespace(before); err != nil {
+			return nil, err
+		}
+		if prev == "" {
+			prev = l.namespace
+		}
+	}
+
+	length := l.alignedLength(prev, before)
+	// every result must be at least 2 apart from neighbors to be able to skip values with the trailing lower char
+	minDist := big.NewInt(int64(2 * (k + 1)))
+	var prevInt, dist *big.Int
+	for {
+		prevInt = l.toIntLength(prev, length)
+		dist = new(big.Int).Sub(l.toIntLength(before, length), prevInt)
+		if dist.Cmp(minDist) >= 0 {
+			break
+		}
+		length += l.blockSize
+	}
+
+	ids := make([]string, k)
+	parts := big.NewInt(int64(k + 1))
+	v := new(big.Int)
+	for i := range ids {
+		v.SetInt64(int64(i + 1))
+		v.Mul(v, dist)
+		v.Quo(v, parts)
+		v.Add(v, prevInt)
+		id := l.fromInt(v, length)
+		if id[length-1] == l.lower && !l.trailingMin {
+			id = id[:length-1] + string(l.nextChar[l.lower])
+		}
+		ids[i] = id
+	}
+	if err := l.checkMaxLength(ids[k-1]); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// shortGreaterNextBeforeN is NextBeforeN in the short greater ordering: the IDs are generated between the swapped bounds
+// in the regular ordering and transformed back in the reverse order
+func (l Lexid) shortGreaterNextBeforeN(prev, before string, k int) ([]string, error) {
+	prevTail, err := l.trimNamespace(prev)
+	if err != nil {
+		return nil, err
+	}
+	beforeTail, err := l.trimNamespace(before)
+	if err != nil {
+		return nil, err
+	}
+	regular := l.withoutNamespace()
+	regular.shortGreater = false
+	rPrev, rBefore := l.regularBounds(prevTail, beforeTail)
+	regularIDs, err := regular.NextBeforeN(rPrev, rBefore, k)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, k)
+	for i, id := range regularIDs {
+		ids[k-1-i] = l.namespace + l.toShortGreater(id)
+	}
+	if err = l.checkMaxLength(ids[0]); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// regularBounds returns the bounds in the regular ordering for IDs between prev and before in the short greater ordering.
+// The order is reversed, so before becomes the lower bound. Empty prev, the start of the list, becomes the upper chars
+// one block longer than before, it's greater than every regular ID up to that length.
+func (l Lexid) regularBounds(prev, before string) (rPrev, rBefore string) {
+	rPrev = l.fromShortGreater(before)
+	if prev == "" {
+		return rPrev, strings.Repeat(string(l.upper), l.alignedLength(rPrev, "")+l.blockSize)
+	}
+	return rPrev, l.fromShortGreater(prev)
+}
+
+func (l Lexid) namespaceNextBefore(prev, before string, trace *Trace) (string, error) {
+	prevTail, err := l.trimNamespace(prev)
+	if err != nil {
+		return "", err
+	}
+	beforeTail, err := l.trimNamespace(before)
+	if err != nil {
+		return "", err
+	}
+	next, err := l.withoutNamespace().nextBeforeTrace(prevTail, beforeTail, trace)
+	if err != nil {
+		return "", err
+	}
+	return l.namespace + next, nil
+}
+
 func (l Lexid) approxDistance(id1, id2 string) (distance int) {
 	var size = len(id2)
 	if len(id1) < len(id2) {
@@ -241,8 +872,44 @@ func (l Lexid) approxDistance(id1, id2 string) (distance int) {
 	return distance
 }
 
+// Middle returns the single block ID in the middle of the ID space.
+// For generators created by NewFractionalCompat it's "a0", the key fractional-indexing generates for an empty list.
+func (l Lexid) Middle() string {
+	if l.fractional {
+		// the first key of fractional-indexing
+		return "a" + string(l.lower)
+	}
+	middle := l.regularMiddle()
+	if l.shortGreater {
+		return l.namespace + l.toShortGreater(middle)
+	}
+	return l.namespace + middle
+}
+
+// regularMiddle returns Middle in the regular ordering without the namespace
+func (l Lexid) regularMiddle() string {
+	middle := l.addTail("")
+	if len(middle) < l.minLength {
+		middle = l.padding(middle, l.minLength-len(middle))
+	}
+	return middle
+}
+
+// FirstChild returns the ID of the first child of the parent in a tree: the parent followed by the middle block,
+// so there is room for children before and after it. The child sorts after the parent and before all IDs
+// greater than the parent that are not its descendants, for example Next(parent) if it keeps the length.
+func (l Lexid) FirstChild(parent string) string {
+	if l.shortGreater {
+		return l.fromRegular(l.regular().FirstChild(l.toRegular(parent)))
+	}
+	for len(parent)%l.blockSize != 0 {
+		parent += string(l.lower)
+	}
+	return l.addTail(parent)
+}
+
 func (l Lexid) addTail(prev string) string {
-	middle := len(l.chars) / 2
+	middle := l.middleIndex
 	prevBytes := []byte(prev)
 	prevBytes = append(prevBytes, l.chars[middle])
 	return l.padding(string(prevBytes), l.blockSize-1)
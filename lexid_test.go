@@ -1,6 +1,8 @@
 package lexid
 
 import (
+	"math"
+	"math/big"
 	"math/rand"
 	"strings"
 	"testing"
@@ -10,6 +12,74 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestNew(t *testing.T) {
+	t.Run("not enough chars", func(t *testing.T) {
+		_, err := New("aaa", 3, 1)
+		assert.Error(t, err)
+	})
+	t.Run("step size", func(t *testing.T) {
+		_, err := New("01", 2, 4)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "max valid stepSize is 3")
+		_, err = New("01", 2, 3)
+		require.NoError(t, err)
+	})
+	t.Run("step size with big capacity", func(t *testing.T) {
+		// 36^20 doesn't fit in int
+		_, err := New(CharsAlphanumericLower, 20, math.MaxInt)
+		require.NoError(t, err)
+		_, err = New("01", 63, math.MaxInt)
+		require.NoError(t, err)
+		_, err = New("01", 62, math.MaxInt)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Contains(t, err.Error(), "max valid stepSize is 4611686018427387903")
+		assert.Equal(t, "126765...205376 (31 digits)", abbreviateInt(new(big.Int).Lsh(big.NewInt(1), 100)))
+	})
+}
+
+func TestNewStrict(t *testing.T) {
+	_, err := NewStrict(CharsAlphanumericLower, 0, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blockSize")
+	_, err = NewStrict(CharsAlphanumericLower, 3, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stepSize")
+	_, err = NewStrict(CharsAlphanumericLower, 3, -1)
+	require.Error(t, err)
+	_, err = NewStrict("a", 3, 1)
+	require.Error(t, err)
+	_, err = NewStrict("aabbcc", 3, 1)
+	require.ErrorIs(t, err, ErrInvalidChars)
+	assert.Contains(t, err.Error(), "'a' is repeated at 1")
+	for _, chars := range []string{CharsAll, CharsAllNoEscape, CharsAlphanumeric, CharsAlphanumericLower, CharsBase64, CharsBase58, CharsUnambiguous} {
+		_, err = NewStrict(chars, 3, 1)
+		assert.NoError(t, err, chars)
+	}
+	lid, err := NewStrict(CharsAlphanumericLower, 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "002", lid.Next(""))
+	// New keeps clamping and removing duplicates
+	lid, err = New(CharsAlphanumericLower, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "2", lid.Next(""))
+	lid, err = New("aabbcc", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "c", lid.Next(""))
+}
+
+func TestMaxStep(t *testing.T) {
+	assert.Equal(t, 3, MaxStep("01", 2))
+	assert.Equal(t, 3, MaxStep("0101", 2))
+	assert.Equal(t, 35, MaxStep(CharsAlphanumericLower, 0))
+	assert.Equal(t, 36*36*36-1, MaxStep(CharsAlphanumericLower, 3))
+	assert.Equal(t, math.MaxInt, MaxStep(CharsAlphanumericLower, 100))
+	assert.Equal(t, 0, MaxStep("a", 3))
+	for _, bs := range []int{1, 2, 3, 4} {
+		_, err := New(CharsBase58, bs, MaxStep(CharsBase58, bs))
+		assert.NoError(t, err)
+	}
+}
+
 func TestLexid_Next(t *testing.T) {
 	t.Run("first id", func(t *testing.T) {
 		lid := Must(CharsAlphanumericLower, 3, 1)
@@ -34,6 +104,16 @@ func TestLexid_Next(t *testing.T) {
 		next = lid.Next("c01")
 		assert.Equal(t, "c02", next)
 	})
+	t.Run("block size 1", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 1, 1)
+		assert.Equal(t, "2", lid.Next(""))
+		assert.Equal(t, "z", lid.Next("y"))
+		assert.Equal(t, "z2", lid.Next("z"))
+		assert.Equal(t, "b1", lid.Next("az"))
+		lid = Must(CharsAlphanumericLower, 1, 10)
+		assert.Equal(t, "ak", lid.Next("aa"))
+		assert.Equal(t, "b9", lid.Next("ay"))
+	})
 	t.Run("next step", func(t *testing.T) {
 		lid := Must(CharsAlphanumericLower, 3, 2)
 		assert.Equal(t, "003", lid.Next("001"))
@@ -42,6 +122,311 @@ func TestLexid_Next(t *testing.T) {
 	})
 }
 
+func TestLexid_NextBytes(t *testing.T) {
+	alphanumeric := []string{"", "0", "01", "zz", "zzz", "zzzzz", "00z", "abz", "abzz"}
+	binary := []string{"", "0", "1", "01", "11", "111", "1111", "1011"}
+	for _, c := range []struct {
+		lid   *Lexid
+		prevs []string
+	}{
+		{Must(CharsAlphanumericLower, 3, 10), alphanumeric},
+		{Must(CharsAlphanumericLower, 1, 5), alphanumeric},
+		{Must(CharsAlphanumericLower, 2, 3).WithNamespace("ab"), alphanumeric},
+		{Must(CharsAlphanumericLower, 2, 3, WithShortGreater(true)), alphanumeric},
+		{Must(CharsAlphanumericLower, 3, 10, WithAllowTrailingMin(true)), alphanumeric},
+		// a step that needs more than one new block
+		{Must("01", 2, 3), binary},
+		{Must("01", 3, 7), binary},
+		{Must("012", 2, 8), binary},
+	} {
+		lid := c.lid
+		for _, prev := range c.prevs {
+			prevBytes := []byte(prev)
+			assert.Equal(t, lid.Next(prev), string(lid.NextBytes(prevBytes)), prev)
+			assert.Equal(t, prev, string(prevBytes))
+		}
+		var id string
+		var idBytes []byte
+		for i := 0; i < 5000; i++ {
+			id = lid.Next(id)
+			idBytes = lid.NextBytes(idBytes)
+			require.Equal(t, id, string(idBytes))
+		}
+	}
+	assert.Equal(t, "111011", string(Must("01", 2, 3).NextBytes([]byte("11"))))
+}
+
+func TestLexid_NextOne(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.Equal(t, "002", lid.NextOne("001"))
+	assert.Equal(t, "011", lid.NextOne("00z"))
+	assert.Equal(t, "zzz002", lid.NextOne("zzz"))
+	assert.Equal(t, "00b", lid.Next("001"))
+}
+
+func TestLexid_PrevOne(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.Equal(t, "001", lid.PrevOne("002"))
+	assert.Equal(t, "00z", lid.PrevOne("011"))
+	assert.Equal(t, "000zzz", lid.PrevOne("001"))
+	assert.Equal(t, "001", lid.Prev("00b"))
+}
+
+func TestLexid_NextStep(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.Equal(t, "006", lid.NextStep("001", 5))
+	assert.Equal(t, "005", lid.PrevStep("00a", 5))
+	assert.Equal(t, Must(CharsAlphanumericLower, 3, 100).Next("i01"), lid.NextStep("i01", 100))
+	assert.Equal(t, Must(CharsAlphanumericLower, 3, 100).Prev("i01"), lid.PrevStep("i01", 100))
+	assert.Equal(t, lid.Next("i01"), lid.NextStep("i01", 10))
+	t.Run("invalid step", func(t *testing.T) {
+		assert.Panics(t, func() { lid.NextStep("001", 0) })
+		assert.Panics(t, func() { lid.PrevStep("001", -1) })
+		assert.Panics(t, func() { lid.NextStep("001", 36*36*36) })
+		assert.NotPanics(t, func() { lid.NextStep("001", 36*36*36-1) })
+	})
+}
+
+func TestLexid_Advance(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.Equal(t, "001", lid.Advance("001", 0))
+	for _, k := range []int{1, 2, 35, 36, 100, 1000} {
+		next, prev := "i01", "i01"
+		for i := 0; i < k; i++ {
+			next = lid.NextOne(next)
+			prev = lid.PrevOne(prev)
+		}
+		assert.Equal(t, next, lid.Advance("i01", k), k)
+		assert.Equal(t, prev, lid.Advance("i01", -k), k)
+	}
+	t.Run("underflow", func(t *testing.T) {
+		prev := "002"
+		for i := 0; i < 5; i++ {
+			prev = lid.PrevOne(prev)
+		}
+		assert.Equal(t, prev, lid.Advance("002", -5))
+		assert.Equal(t, "000zzw", prev)
+	})
+}
+
+func TestLexid_NextFixed(t *testing.T) {
+	lid := Must("0123", 2, 3)
+	next, err := lid.NextFixed("")
+	require.NoError(t, err)
+	assert.Equal(t, "11", next)
+	var prev = next
+	for {
+		next, err = lid.NextFixed(prev)
+		if err != nil {
+			break
+		}
+		assert.Len(t, next, 2)
+		assert.Greater(t, next, prev)
+		prev = next
+	}
+	assert.Equal(t, "31", prev)
+	assert.Equal(t, "3111", lid.Next(prev))
+	_, err = lid.NextFixed("31")
+	assert.ErrorIs(t, err, ErrExhausted)
+
+	// unaligned prev is padded to the block
+	next, err = lid.NextFixed("1")
+	require.NoError(t, err)
+	assert.Equal(t, "11", next)
+	lid = Must("abcdefghijklmnopqrstuvwxyz", 3, 1)
+	next, err = lid.NextFixed("c")
+	require.NoError(t, err)
+	assert.Equal(t, "cab", next)
+	next, err = lid.NextFixed("zz")
+	require.NoError(t, err)
+	assert.Equal(t, "zzb", next)
+}
+
+func TestLexid_NextInfo(t *testing.T) {
+	lid := Must("0123", 2, 3)
+	next, grew := lid.NextInfo("")
+	assert.Equal(t, "11", next)
+	assert.False(t, grew)
+	next, grew = lid.NextInfo("1")
+	assert.Equal(t, "11", next)
+	assert.False(t, grew)
+	next, grew = lid.NextInfo("31")
+	assert.Equal(t, "3111", next)
+	assert.True(t, grew)
+	// grows only when the current length is exhausted
+	next, grew = lid.NextInfo("1023")
+	assert.Equal(t, "1033", next)
+	assert.False(t, grew)
+}
+
+func TestLexid_Prev(t *testing.T) {
+	t.Run("prev", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Equal(t, "001", lid.Prev("002"))
+		assert.Equal(t, "00z", lid.Prev("011"))
+		assert.Equal(t, "bzz", lid.Prev("c"))
+		assert.Equal(t, "000zzz", lid.Prev(""))
+	})
+	t.Run("trailing zero", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Equal(t, "000zzz", lid.Prev("001"))
+		assert.Equal(t, "000zzy", lid.Prev("000zzz"))
+		assert.Equal(t, "000000zzz", lid.Prev("000001"))
+	})
+	t.Run("block size 1", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 1, 1)
+		assert.Equal(t, "y", lid.Prev("z"))
+		assert.Equal(t, "0z", lid.Prev("1"))
+		assert.Equal(t, "az", lid.Prev("b1"))
+		lid = Must(CharsAlphanumericLower, 1, 10)
+		assert.Equal(t, "aa", lid.Prev("ak"))
+		assert.Equal(t, "ay", lid.Prev("b9"))
+	})
+	t.Run("prev step", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 4, 6)
+		assert.Equal(t, "0000zzzu", lid.Prev("0001"))
+		lid = Must(CharsAlphanumericLower, 3, 2)
+		assert.Equal(t, "001", lid.Prev("003"))
+		assert.Equal(t, "00y", lid.Prev("011"))
+	})
+	t.Run("prev of next", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 100)
+		var prev, next string
+		for i := 0; i < 10000; i++ {
+			next = lid.Next(prev)
+			if len(next) == len(prev) {
+				assert.Equal(t, prev, lid.Prev(next))
+			}
+			prev = next
+		}
+	})
+	t.Run("sorted", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 2, 30)
+		var next = "zz"
+		for i := 0; i < 10000; i++ {
+			prev := lid.Prev(next)
+			assert.Greater(t, next, prev)
+			assert.False(t, strings.HasSuffix(prev, "0"), prev)
+			next = prev
+		}
+		t.Log(next)
+	})
+}
+
+func TestLexid_Neighbors(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	for _, id := range []string{"001", "00a", "i01", "zzz", "c", "000zzz", "abc001", lid.Middle()} {
+		prev, next := lid.Neighbors(id)
+		assert.Equal(t, lid.Prev(id), prev)
+		assert.Equal(t, lid.Next(id), next)
+		assert.Less(t, prev, id)
+		assert.Greater(t, next, id)
+	}
+}
+
+func TestLexid_PrevOrMin(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	prev, isMin := lid.PrevOrMin("001")
+	assert.Equal(t, "001", prev)
+	assert.True(t, isMin)
+	prev, isMin = lid.PrevOrMin("003")
+	assert.Equal(t, "001", prev)
+	assert.True(t, isMin)
+	prev, isMin = lid.PrevOrMin("")
+	assert.Equal(t, "001", prev)
+	assert.True(t, isMin)
+	prev, isMin = lid.PrevOrMin("000zzz")
+	assert.Equal(t, lid.Prev("000zzz"), prev)
+	assert.False(t, isMin)
+	prev, isMin = lid.PrevOrMin("000003")
+	assert.Equal(t, "000001", prev)
+	assert.True(t, isMin)
+	next := "zzz"
+	for i := 0; i < 1000; i++ {
+		prev, isMin = lid.PrevOrMin(next)
+		require.False(t, isMin)
+		require.Equal(t, lid.Prev(next), prev)
+		next = prev
+	}
+	t.Run("namespace", func(t *testing.T) {
+		ns := lid.WithNamespace("abc")
+		prev, isMin := ns.PrevOrMin("abc002")
+		assert.Equal(t, "abc001", prev)
+		assert.True(t, isMin)
+	})
+	t.Run("short greater", func(t *testing.T) {
+		sg := Must(CharsAlphanumericLower, 3, 10, WithShortGreater(true))
+		prev, isMin := sg.PrevOrMin(sg.toShortGreater("zzy"))
+		assert.Equal(t, sg.toShortGreater("zzz"), prev)
+		assert.True(t, isMin)
+		prev, isMin = sg.PrevOrMin(sg.Middle())
+		assert.Equal(t, sg.Prev(sg.Middle()), prev)
+		assert.False(t, isMin)
+	})
+}
+
+func TestLexid_PrevBounded(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10)
+	prev, hit := lid.PrevBounded("00z", "005")
+	assert.Equal(t, lid.Prev("00z"), prev)
+	assert.False(t, hit)
+
+	// Prev("00k") is "00a" which is not above the floor
+	prev, hit = lid.PrevBounded("00k", "00a")
+	assert.True(t, hit)
+	assert.Greater(t, prev, "00a")
+	assert.Less(t, prev, "00k")
+
+	prev, hit = lid.PrevBounded("00k", "00j")
+	assert.True(t, hit)
+	assert.Greater(t, prev, "00j")
+	assert.Less(t, prev, "00k")
+
+	prev, hit = lid.PrevBounded("00k", "00k")
+	assert.Equal(t, "", prev)
+	assert.True(t, hit)
+
+	// the empty floor is no bound, Prev grows the id by the padding block
+	prev, hit = lid.PrevBounded("001", "")
+	assert.Equal(t, lid.Prev("001"), prev)
+	assert.False(t, hit)
+
+	t.Run("stays above the floor", func(t *testing.T) {
+		next, floor := "zzz", "zzh"
+		for i := 0; i < 100; i++ {
+			prev, _ := lid.PrevBounded(next, floor)
+			require.Greater(t, prev, floor)
+			require.Less(t, prev, next)
+			next = prev
+		}
+	})
+	t.Run("trailing min", func(t *testing.T) {
+		tm := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithAllowTrailingMin(true))
+		prev, hit := tm.PrevBounded("00a", "")
+		assert.Equal(t, "000", prev)
+		assert.False(t, hit)
+		prev, hit = tm.PrevBounded("000", "")
+		assert.Equal(t, "", prev)
+		assert.True(t, hit)
+	})
+}
+
+func TestLexid_CanPrev(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.True(t, lid.CanPrev("002", 1))
+	assert.False(t, lid.CanPrev("002", 2))
+	assert.False(t, lid.CanPrev("001", 1))
+	// 00z, 00y, ..., 001 skipping 010
+	assert.True(t, lid.CanPrev("011", 35))
+	assert.False(t, lid.CanPrev("011", 36))
+	assert.True(t, lid.CanPrev("000zzz", 10))
+	assert.False(t, lid.CanPrev("", 1))
+	assert.False(t, lid.CanPrev("01", 1))
+	for _, steps := range []int{1, 10, 1000, 45359, 45360} {
+		assert.Equal(t, len(lid.prevStep("zzz", steps)) == 3, lid.CanPrev("zzz", steps), steps)
+	}
+}
+
 func TestLexid_NextBefore(t *testing.T) {
 	t.Run("empty before", func(t *testing.T) {
 		lid := Must(CharsAlphanumericLower, 3, 100)
@@ -109,6 +494,228 @@ func TestLexid_NextBefore(t *testing.T) {
 	})
 }
 
+func TestLexid_NextBeforePrefixBoundary(t *testing.T) {
+	t.Run("regression seeds", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 10)
+		for _, tc := range []struct {
+			prev, before, next string
+		}{
+			{"zzz", "zzz001", "zzz000i01"},
+			{"", "001", "000i01"},
+			{"001", "001001", "001000i01"},
+			{"abc", "abc001", "abc000i01"},
+			{"zzz", "zzz000001", "zzz000000i01"},
+			{"zzzzzz", "zzzzzz001", "zzzzzz000i01"},
+		} {
+			next, trace, err := lid.NextBeforeTrace(tc.prev, tc.before)
+			require.NoError(t, err)
+			assert.Equal(t, tc.next, next, "'%s' and '%s'", tc.prev, tc.before)
+			assert.True(t, trace.Tight)
+			assert.True(t, trace.MinTail)
+		}
+		// a near-minimal tail with room at the length of before keeps the length
+		next, trace, err := lid.NextBeforeTrace("zzz", "zzz002")
+		require.NoError(t, err)
+		assert.Equal(t, "zzz001", next)
+		assert.False(t, trace.Tight)
+	})
+	t.Run("grows by one block", func(t *testing.T) {
+		for _, bs := range []int{1, 2, 3} {
+			for _, step := range []int{1, 3} {
+				lid := Must("0123", bs, step)
+				for _, prev := range []string{"", lid.padding("", bs), "3333"[:bs], lid.Next(lid.Next(""))} {
+					for _, tail := range []string{lid.padding("", bs), lid.padding("", 2*bs), lid.padding("", bs) + lid.padding("", bs)} {
+						before := prev + tail
+						next, err := lid.NextBefore(prev, before)
+						require.NoError(t, err)
+						assert.Greater(t, next, prev)
+						assert.Less(t, next, before)
+						assert.NoError(t, lid.Validate(next))
+						if tail == lid.padding("", len(tail)) {
+							assert.Len(t, next, len(before)+bs, "'%s' and '%s'", prev, before)
+						} else {
+							assert.LessOrEqual(t, len(next), len(before), "'%s' and '%s'", prev, before)
+						}
+					}
+				}
+			}
+		}
+	})
+	t.Run("bulk prepends", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 10)
+		first := lid.Next("")
+		for i := 0; i < 300; i++ {
+			next, err := lid.NextBefore("", first)
+			require.NoError(t, err)
+			require.Less(t, next, first)
+			require.NoError(t, lid.Validate(next))
+			// the growth is linear, not bounded: after "003" and "001" every grown block takes 4 prepends,
+			// its midpoint "i01" and then the scaled steps "00b", "003" and "001", so the length grows by 3 every 4 prepends
+			require.LessOrEqual(t, len(next), 3*((i+2)/4+1), i)
+			first = next
+		}
+		assert.Len(t, first, 228)
+	})
+	t.Run("trailing min", func(t *testing.T) {
+		lid := Must("0123", 1, 1, WithAllowTrailingMin(true))
+		// nothing sorts between an id and the id followed by lower chars only, so such ids are never returned
+		for _, bounds := range [][2]string{{"", "00"}, {"1", "100"}, {"1", "10"}} {
+			_, err := lid.NextBefore(bounds[0], bounds[1])
+			assert.ErrorIs(t, err, ErrNoMidpoint, bounds[0])
+		}
+		next, err := lid.NextBefore("", "1")
+		require.NoError(t, err)
+		assert.Equal(t, "02", next)
+		next, err = lid.NextBefore("1", "101")
+		require.NoError(t, err)
+		assert.Equal(t, "1002", next)
+
+		tm := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 1, WithAllowTrailingMin(true))
+		next, err = tm.NextBefore("", "001")
+		require.NoError(t, err)
+		assert.Equal(t, "000i00", next)
+		digits := Must("0123456789", 1, 1, WithAllowTrailingMin(true))
+		next, err = digits.NextBefore("33333", "33334")
+		require.NoError(t, err)
+		assert.NotEqual(t, "333330", next)
+		_, err = digits.NextBefore("33333", next)
+		assert.NoError(t, err)
+	})
+	t.Run("trailing min front inserts", func(t *testing.T) {
+		for _, lid := range []*Lexid{
+			Must("0123", 1, 1, WithAllowTrailingMin(true)),
+			Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithAllowTrailingMin(true)),
+		} {
+			first := lid.Next("")
+			for i := 0; i < 300; i++ {
+				next, err := lid.NextBefore("", first)
+				require.NoError(t, err, first)
+				require.Less(t, next, first)
+				require.NoError(t, lid.Validate(next))
+				require.NotEqual(t, strings.Repeat(string(lid.lower), len(next)), next)
+				first = next
+			}
+		}
+	})
+}
+
+func TestLexid_NextBeforeTrace(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("step", func(t *testing.T) {
+		next, trace, err := lid.NextBeforeTrace("001", "00b")
+		require.NoError(t, err)
+		assert.Equal(t, "003", next)
+		assert.Equal(t, Trace{Dist: 10, Step: 2}, trace)
+	})
+	t.Run("add tail", func(t *testing.T) {
+		next, trace, err := lid.NextBeforeTrace("001", "002")
+		require.NoError(t, err)
+		assert.Len(t, next, 6)
+		assert.Equal(t, Trace{Dist: 1, AddTail: true}, trace)
+	})
+	t.Run("min tail", func(t *testing.T) {
+		next, trace, err := lid.NextBeforeTrace("zzz", "zzz001")
+		require.NoError(t, err)
+		assert.Len(t, next, 9)
+		assert.True(t, trace.Prefix)
+		assert.True(t, trace.MinTail)
+		assert.True(t, trace.Tight)
+	})
+	t.Run("minimal", func(t *testing.T) {
+		next, trace, err := lid.NextBeforeTrace("", "005zzz")
+		require.NoError(t, err)
+		assert.Len(t, next, 3)
+		assert.True(t, trace.Prefix)
+		assert.True(t, trace.Minimal)
+	})
+	t.Run("namespace", func(t *testing.T) {
+		_, trace, err := lid.WithNamespace("abc").NextBeforeTrace("abc001", "abc00b")
+		require.NoError(t, err)
+		assert.Equal(t, Trace{Dist: 10, Step: 2}, trace)
+	})
+	t.Run("same as NextBefore", func(t *testing.T) {
+		ids := lid.GenerateAfter("", 100)
+		for i := 0; i+1 < len(ids); i++ {
+			expected, err := lid.NextBefore(ids[i], ids[i+1])
+			require.NoError(t, err)
+			next, _, err := lid.NextBeforeTrace(ids[i], ids[i+1])
+			require.NoError(t, err)
+			assert.Equal(t, expected, next)
+		}
+		_, _, err := lid.NextBeforeTrace("002", "001")
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+}
+
+func TestLexid_FirstChild(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.Equal(t, "abci01", lid.FirstChild("abc"))
+	assert.Equal(t, "ab0i01", lid.FirstChild("ab"))
+	var parent string
+	for i := 0; i < 1000; i++ {
+		parent = lid.Next(parent)
+		child := lid.FirstChild(parent)
+		assert.True(t, lid.IsValid(child), child)
+		assert.Greater(t, child, parent)
+		assert.Less(t, child, lid.Next(parent))
+		// room for siblings on both sides
+		_, err := lid.NextBefore(parent, child)
+		assert.NoError(t, err)
+		assert.Len(t, lid.Next(child), len(child))
+	}
+}
+
+func TestLexid_NextBeforeN(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	check := func(t *testing.T, prev, before string, ids []string, k int) {
+		require.Len(t, ids, k)
+		for i, id := range ids {
+			assert.Greater(t, id, prev)
+			assert.Less(t, id, before)
+			assert.Zero(t, len(id)%3, id)
+			assert.False(t, strings.HasSuffix(id, "0"), id)
+			if i > 0 {
+				assert.Greater(t, id, ids[i-1])
+			}
+		}
+	}
+	t.Run("same length", func(t *testing.T) {
+		ids, err := lid.NextBeforeN("001", "00z", 10)
+		require.NoError(t, err)
+		check(t, "001", "00z", ids, 10)
+		assert.Len(t, ids[9], 3)
+	})
+	t.Run("grow", func(t *testing.T) {
+		ids, err := lid.NextBeforeN("001", "002", 300)
+		require.NoError(t, err)
+		check(t, "001", "002", ids, 300)
+		assert.Len(t, ids[0], 6)
+	})
+	t.Run("empty prev and padding", func(t *testing.T) {
+		ids, err := lid.NextBeforeN("", "001", 5)
+		require.NoError(t, err)
+		check(t, "", "001", ids, 5)
+		ids, err = lid.NextBeforeN("zzz", "zzz001", 5)
+		require.NoError(t, err)
+		check(t, "zzz", "zzz001", ids, 5)
+		ids, err = lid.NextBeforeN("a", "b", 5)
+		require.NoError(t, err)
+		check(t, "a", "b", ids, 5)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := lid.NextBeforeN("002", "001", 5)
+		assert.Error(t, err)
+		ids, err := lid.NextBeforeN("001", "002", 0)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestLexid_Middle(t *testing.T) {
+	assert.Equal(t, "i01", Must(CharsAlphanumericLower, 3, 1).Middle())
+	assert.Equal(t, "V", Must(CharsAlphanumeric, 1, 1).Middle())
+}
+
 func TestLexid_Fuzzy(t *testing.T) {
 	lid := Must(CharsAllNoEscape, 4, 100)
 	rand.Seed(time.Now().UnixNano())
@@ -176,6 +783,24 @@ func BenchmarkLexid_Next(b *testing.B) {
 		}
 	}
 
+	// the bs=1 fast path is measured on a fixed set of prevs of the same length,
+	// otherwise the chained IDs grow by a char on every carry and the cost is dominated by the length
+	fixed := func(b *testing.B, lid *Lexid) {
+		prevs := lid.Spread(1024)
+		b.ReportAllocs()
+		b.ResetTimer()
+		var next string
+		for i := 0; i < b.N; i++ {
+			next = lid.Next(prevs[i%len(prevs)])
+		}
+		_ = next
+	}
+	b.Run("bs=1;step=1;fixed", func(b *testing.B) {
+		fixed(b, Must(CharsAllNoEscape, 1, 1))
+	})
+	b.Run("bs=2;step=1;fixed", func(b *testing.B) {
+		fixed(b, Must(CharsAllNoEscape, 2, 1))
+	})
 	b.Run("bs=4;step=1", func(b *testing.B) {
 		bench(b, Must(CharsAllNoEscape, 4, 1))
 	})
@@ -184,3 +809,50 @@ func BenchmarkLexid_Next(b *testing.B) {
 	})
 
 }
+
+func TestLexid_PrependBudget(t *testing.T) {
+	countPrev := func(lid *Lexid, id string) int {
+		var n int
+		for {
+			prev, grew := lid.prevStepInfo(id, lid.stepSize)
+			if grew {
+				return n
+			}
+			id = prev
+			n++
+		}
+	}
+	t.Run("step 1", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Equal(t, 0, lid.PrependBudget("001"))
+		assert.Equal(t, 0, lid.PrependBudget(""))
+		assert.Equal(t, 1, lid.PrependBudget("002"))
+		assert.Equal(t, 35, lid.PrependBudget("011"))
+		for _, id := range []string{"01", "00z", "0zz", "zzz", "000zzz"} {
+			assert.Equal(t, countPrev(lid, id), lid.PrependBudget(id), id)
+		}
+	})
+	t.Run("step 7", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 2, 7)
+		for _, id := range []string{"01", "0z", "10", "11", "az", "zz"} {
+			assert.Equal(t, countPrev(lid, id), lid.PrependBudget(id), id)
+		}
+	})
+	t.Run("block size 1", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 1, 3)
+		for _, id := range []string{"1", "3", "4", "z", "z1", "zzz"} {
+			assert.Equal(t, countPrev(lid, id), lid.PrependBudget(id), id)
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 2, 1).WithNamespace("ab")
+		assert.Equal(t, 1, lid.PrependBudget("ab02"))
+		assert.Equal(t, countPrev(lid, "abz1"), lid.PrependBudget("abz1"))
+	})
+	t.Run("short greater", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 2, 3, WithShortGreater(true))
+		for _, id := range []string{lid.Next(""), lid.Middle(), lid.Prev(lid.Middle())} {
+			assert.Equal(t, countPrev(lid, id), lid.PrependBudget(id), id)
+		}
+	})
+}
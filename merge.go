@@ -0,0 +1,59 @@
+package lexid
+
+import "fmt"
+
+// Merge merges two sorted slices of IDs into one sorted slice, for example lists generated independently by offline clients.
+// An ID present in both slices is kept once as is, the second copy is replaced by a new ID between the first copy
+// and the following ID, so the result is strictly sorted and has all len(a)+len(b) items.
+// It returns an error if a slice is not sorted or there is no room for a new ID within the max length.
+func (l Lexid) Merge(a, b []string) ([]string, error) {
+	for _, ids := range [][]string{a, b} {
+		for i := 1; i < len(ids); i++ {
+			if !l.less(ids[i-1], ids[i]) {
+				return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrNotSorted, ids[i], ids[i-1])
+			}
+		}
+	}
+	res := make([]string, 0, len(a)+len(b))
+	var i, j int
+	for i < len(a) && j < len(b) {
+		switch {
+		case l.less(a[i], b[j]):
+			res = append(res, a[i])
+			i++
+		case l.less(b[j], a[i]):
+			res = append(res, b[j])
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+			key, err := l.mergeKey(res[len(res)-1], a[i:], b[j:])
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, key)
+		}
+	}
+	res = append(res, a[i:]...)
+	return append(res, b[j:]...), nil
+}
+
+// mergeKey returns a new ID after prev and before the heads of the remaining slices
+func (l Lexid) mergeKey(prev string, a, b []string) (string, error) {
+	var before string
+	switch {
+	case len(a) > 0 && len(b) > 0:
+		before = a[0]
+		if l.less(b[0], before) {
+			before = b[0]
+		}
+	case len(a) > 0:
+		before = a[0]
+	case len(b) > 0:
+		before = b[0]
+	default:
+		return l.NextErr(prev)
+	}
+	return l.NextBefore(prev, before)
+}
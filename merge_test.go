@@ -0,0 +1,59 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Merge(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	checkSorted := func(t *testing.T, ids []string) {
+		for i := 1; i < len(ids); i++ {
+			require.Less(t, ids[i-1], ids[i])
+		}
+	}
+	t.Run("no collisions", func(t *testing.T) {
+		res, err := lid.Merge([]string{"001", "003"}, []string{"002", "004", "005"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"001", "002", "003", "004", "005"}, res)
+		res, err = lid.Merge(nil, []string{"002"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"002"}, res)
+	})
+	t.Run("same seed", func(t *testing.T) {
+		a := lid.GenerateAfter("", 100)
+		b := lid.GenerateAfter("", 50)
+		res, err := lid.Merge(a, b)
+		require.NoError(t, err)
+		assert.Len(t, res, 150)
+		checkSorted(t, res)
+		assert.Equal(t, a[0], res[0])
+	})
+	t.Run("collision at the end", func(t *testing.T) {
+		res, err := lid.Merge([]string{"001", "00b"}, []string{"00b"})
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		checkSorted(t, res)
+	})
+	t.Run("interleaved collisions", func(t *testing.T) {
+		a := []string{"001", "002", "005", "007"}
+		b := []string{"002", "003", "005", "006", "007", "008"}
+		res, err := lid.Merge(a, b)
+		require.NoError(t, err)
+		assert.Len(t, res, 10)
+		checkSorted(t, res)
+	})
+	t.Run("not sorted", func(t *testing.T) {
+		_, err := lid.Merge([]string{"002", "001"}, nil)
+		assert.ErrorIs(t, err, ErrNotSorted)
+		_, err = lid.Merge(nil, []string{"001", "001"})
+		assert.ErrorIs(t, err, ErrNotSorted)
+	})
+	t.Run("max length", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 10, WithMaxLength(3))
+		_, err := lid.Merge([]string{"001", "002"}, []string{"001"})
+		assert.ErrorIs(t, err, ErrMaxLength)
+	})
+}
@@ -0,0 +1,39 @@
+package lexid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithNamespace returns a copy of the Lexid that prefixes all generated IDs with the namespace block.
+// IDs of different namespaces never collide and IDs within a namespace keep their order.
+// IDs passed to the copy must be generated within the same namespace.
+// It panics if ns is not a valid block: blockSize chars of the alphabet.
+func (l *Lexid) WithNamespace(ns string) *Lexid {
+	if len(ns) != l.blockSize {
+		panic(fmt.Errorf("%w: namespace '%s' must be exactly %d chars", ErrInvalidConfig, ns, l.blockSize))
+	}
+	if err := l.checkChars(ns); err != nil {
+		panic(err)
+	}
+	nl := *l
+	nl.namespace = ns
+	return &nl
+}
+
+// withoutNamespace returns a copy of the Lexid working with IDs without the namespace
+func (l Lexid) withoutNamespace() Lexid {
+	l.namespace = ""
+	return l
+}
+
+// trimNamespace removes the namespace from the id; it returns an error if a non-empty id is not in the namespace
+func (l Lexid) trimNamespace(id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(id, l.namespace) {
+		return "", fmt.Errorf("%w: '%s' is not in the namespace '%s'", ErrInvalidID, id, l.namespace)
+	}
+	return id[len(l.namespace):], nil
+}
@@ -0,0 +1,63 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_WithNamespace(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("invalid namespace", func(t *testing.T) {
+		assert.Panics(t, func() { lid.WithNamespace("ab") })
+		assert.Panics(t, func() { lid.WithNamespace("aB1") })
+	})
+	t.Run("no collisions", func(t *testing.T) {
+		a := lid.WithNamespace("aaa")
+		b := lid.WithNamespace("bbb")
+		assert.Equal(t, "aaai01", a.Middle())
+		assert.Equal(t, "bbbi01", b.Middle())
+		seen := map[string]bool{}
+		prevA, prevB := a.Middle(), b.Middle()
+		for i := 0; i < 5000; i++ {
+			nextA, nextB := a.Next(prevA), b.Next(prevB)
+			assert.Greater(t, nextA, prevA)
+			assert.Greater(t, nextB, prevB)
+			assert.Less(t, nextA, "bbb")
+			assert.Greater(t, nextB, "bbb")
+			assert.False(t, seen[nextA] || seen[nextB])
+			seen[nextA], seen[nextB] = true, true
+			prevA, prevB = nextA, nextB
+		}
+		assert.Equal(t, "aaa"+lid.Next(""), a.Next(""))
+		assert.Equal(t, "aaa"+lid.Prev("i01"), a.Prev(a.Middle()))
+	})
+	t.Run("next before", func(t *testing.T) {
+		a := lid.WithNamespace("aaa")
+		next, err := a.NextBefore("aaa001", "aaa002")
+		require.NoError(t, err)
+		assert.Greater(t, next, "aaa001")
+		assert.Less(t, next, "aaa002")
+		next, err = a.NextBefore("", "aaa001")
+		require.NoError(t, err)
+		assert.Greater(t, next, "aaa")
+		assert.Less(t, next, "aaa001")
+		_, err = a.NextBefore("aaa001", "bbb001")
+		assert.Error(t, err)
+		ids, err := a.NextBeforeN("", "aaa001", 3)
+		require.NoError(t, err)
+		for _, id := range ids {
+			assert.Greater(t, id, "aaa")
+			assert.Less(t, id, "aaa001")
+		}
+		_, err = a.NextBeforeN("", "bbb", 3)
+		assert.Error(t, err)
+	})
+	t.Run("spread", func(t *testing.T) {
+		a := lid.WithNamespace("aaa")
+		for i, id := range a.Spread(10) {
+			assert.Equal(t, "aaa"+lid.Spread(10)[i], id)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package lexid
+
+import "math/big"
+
+// Nearest returns the candidate with the smallest exact distance to the target and its index,
+// for example to snap a dropped item to the closest slot. The target and all candidates are padded with lower chars
+// to the same length, so IDs of different lengths are compared by their positions in the keyspace.
+// Ties break toward the lower candidate. The target must consist of alphabet chars,
+// candidates with other chars are skipped and it returns "", -1 if no candidate is left.
+func (l Lexid) Nearest(target string, candidates []string) (string, int) {
+	length := len(target)
+	for _, c := range candidates {
+		if len(c) > length {
+			length = len(c)
+		}
+	}
+	vt := l.toIntLength(target, length)
+	best := -1
+	var bestDist *big.Int
+	dist := new(big.Int)
+	for i, c := range candidates {
+		if l.checkChars(c) != nil {
+			continue
+		}
+		dist.Sub(l.toIntLength(c, length), vt)
+		dist.Abs(dist)
+		if best == -1 {
+			best, bestDist = i, new(big.Int).Set(dist)
+			continue
+		}
+		if cmp := dist.Cmp(bestDist); cmp < 0 || cmp == 0 && l.less(c, candidates[best]) {
+			best = i
+			bestDist.Set(dist)
+		}
+	}
+	if best == -1 {
+		return "", -1
+	}
+	return candidates[best], best
+}
@@ -0,0 +1,48 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Nearest(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 1)
+	candidates := []string{"001", "00a", "00z", "010"}
+
+	id, i := lid.Nearest("00c", candidates)
+	assert.Equal(t, "00a", id)
+	assert.Equal(t, 1, i)
+
+	id, i = lid.Nearest("zzz", candidates)
+	assert.Equal(t, "010", id)
+	assert.Equal(t, 3, i)
+
+	t.Run("ties", func(t *testing.T) {
+		// "00c" is 2 from both "00a" and "00e"
+		id, i := lid.Nearest("00c", []string{"00e", "00a"})
+		assert.Equal(t, "00a", id)
+		assert.Equal(t, 1, i)
+		id, i = lid.Nearest("00c", []string{"00a", "00e"})
+		assert.Equal(t, "00a", id)
+		assert.Equal(t, 0, i)
+	})
+	t.Run("different lengths", func(t *testing.T) {
+		// "00a" is "00a000" padded
+		id, _ := lid.Nearest("00a001", []string{"00a", "00a00z", "00b"})
+		assert.Equal(t, "00a", id)
+		id, _ = lid.Nearest("00az", []string{"00a", "00b001"})
+		assert.Equal(t, "00b001", id)
+	})
+	t.Run("no candidates", func(t *testing.T) {
+		id, i := lid.Nearest("00c", nil)
+		assert.Equal(t, "", id)
+		assert.Equal(t, -1, i)
+		id, i = lid.Nearest("00c", []string{"00C", "0!a"})
+		assert.Equal(t, "", id)
+		assert.Equal(t, -1, i)
+		id, i = lid.Nearest("00c", []string{"00C", "00f"})
+		assert.Equal(t, "00f", id)
+		assert.Equal(t, 1, i)
+	})
+}
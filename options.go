@@ -0,0 +1,184 @@
+package lexid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Option configures a Lexid created by New
+type Option func(l *Lexid) error
+
+// WithMaxLength limits the length of IDs: NextErr, PrevErr, NextBefore and NextBeforeN return an error instead of an ID longer than n.
+// Next and Prev ignore the limit. n must be a multiple of blockSize.
+func WithMaxLength(n int) Option {
+	return func(l *Lexid) error {
+		if n < l.blockSize || n%l.blockSize != 0 {
+			return fmt.Errorf("%w: maxLength (%d) must be a positive multiple of blockSize (%d)", ErrInvalidConfig, n, l.blockSize)
+		}
+		if n < l.minLength {
+			return fmt.Errorf("%w: maxLength (%d) must not be less than minLength (%d)", ErrInvalidConfig, n, l.minLength)
+		}
+		l.maxLength = n
+		return nil
+	}
+}
+
+// checkMaxLength returns an error if the id is longer than the max length
+func (l Lexid) checkMaxLength(id string) error {
+	if l.maxLength > 0 && len(id) > l.maxLength {
+		return fmt.Errorf("%w: id '%s' is longer than %d", ErrMaxLength, id, l.maxLength)
+	}
+	return nil
+}
+
+// WithMinLength pads generated IDs to at least n chars: Next("") and Prev("") start at n chars
+// and shorter IDs passed to Next, Prev and NextBefore are padded with lower chars first. n must be a multiple of blockSize.
+func WithMinLength(n int) Option {
+	return func(l *Lexid) error {
+		if n < l.blockSize || n%l.blockSize != 0 {
+			return fmt.Errorf("%w: minLength (%d) must be a positive multiple of blockSize (%d)", ErrInvalidConfig, n, l.blockSize)
+		}
+		if l.maxLength > 0 && n > l.maxLength {
+			return fmt.Errorf("%w: minLength (%d) must not be greater than maxLength (%d)", ErrInvalidConfig, n, l.maxLength)
+		}
+		l.minLength = n
+		return nil
+	}
+}
+
+// minIDLength returns the length of the shortest generated ID
+func (l Lexid) minIDLength() int {
+	if l.minLength > l.blockSize {
+		return l.minLength
+	}
+	return l.blockSize
+}
+
+// padMinLength pads the id with lower chars up to the min length, the value of the id stays the same
+func (l Lexid) padMinLength(id string) string {
+	if len(id) >= l.minLength {
+		return id
+	}
+	return id + strings.Repeat(string(l.lower), l.minLength-len(id))
+}
+
+// WithStrictPrev makes PrevErr return ErrExhausted instead of an ID grown by the padding block of upper chars,
+// for example "000zzz" for "001", so the caller can rebalance. Prev ignores the option.
+func WithStrictPrev(enabled bool) Option {
+	return func(l *Lexid) error {
+		l.strictPrev = enabled
+		return nil
+	}
+}
+
+// WithAllowTrailingMin allows IDs ending with the lower char, so the whole keyspace of a length is usable,
+// for example "aa0" between "a9z" and "aa1" for fixed-width keys. Validate accepts such IDs, Next and NextFixed step over them
+// and NextBefore and CountBetween count them in the gaps. The invariant is what lets Prev append the padding block,
+// so with the option Prev steps down at the same length instead: it stops at the ID of lower chars only,
+// which is the least ID of any length, and returns the empty string for it as nothing sorts before it.
+// Next still steps from the padding ending with the char after lower for the empty prev and grown IDs to keep room before them.
+// NextBefore never returns prev followed by lower chars only, for example "abc000" after "abc" or "000" after the empty prev:
+// nothing sorts between them, so it picks a longer ID instead.
+func WithAllowTrailingMin(enabled bool) Option {
+	return func(l *Lexid) error {
+		l.trailingMin = enabled
+		return nil
+	}
+}
+
+// EmptyStart is the ID Next and Prev step from for the empty id, see WithEmptyStart
+type EmptyStart struct {
+	middle bool
+	id     string
+}
+
+var (
+	// StartMin steps from the first ID of the min length, for example "001", it's the default
+	StartMin = EmptyStart{}
+	// StartMiddle steps from Middle, so there is room on both sides of new sequences
+	StartMiddle = EmptyStart{middle: true}
+)
+
+// StartAt steps from the literal id, it must be a valid ID in the regular ordering without the namespace
+func StartAt(id string) EmptyStart {
+	return EmptyStart{id: id}
+}
+
+// WithEmptyStart sets the origin Next("") and Prev("") step from instead of the first ID, so callers don't special-case
+// the empty seed. It returns an error if the literal start of StartAt is not a valid ID of the alphabet.
+func WithEmptyStart(start EmptyStart) Option {
+	return func(l *Lexid) error {
+		if start.id != "" {
+			regular := *l
+			regular.namespace, regular.shortGreater = "", false
+			if err := regular.Validate(start.id); err != nil {
+				return fmt.Errorf("%w: empty start: %v", ErrInvalidConfig, err)
+			}
+		}
+		l.emptyStart = start
+		return nil
+	}
+}
+
+// emptyOrigin returns the regular ID Next and Prev step from for the empty id, see WithEmptyStart
+func (l Lexid) emptyOrigin() string {
+	switch {
+	case l.emptyStart.middle:
+		return l.regularMiddle()
+	case l.emptyStart.id != "":
+		return l.padMinLength(l.emptyStart.id)
+	}
+	return l.padding("", l.minIDLength())
+}
+
+// WithCaseFold makes Canonicalize map letters of the wrong case to the letters of the alphabet, for human-entered codes.
+// It returns an error if the alphabet contains both cases of a letter: they are different chars with different positions,
+// so folding them would merge distinct IDs.
+func WithCaseFold() Option {
+	return func(l *Lexid) error {
+		for _, c := range l.chars {
+			if isLetter(c) && l.charIndex[c^0x20] != -1 {
+				return fmt.Errorf("%w: case fold is not possible, the alphabet contains both '%c' and '%c'", ErrInvalidConfig, c, c^0x20)
+			}
+		}
+		l.caseFold = true
+		return nil
+	}
+}
+
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// WithMiddleIndex sets the index of the alphabet char used by Middle and by NextBefore for appended tails instead of len(chars)/2.
+// A lower index leaves more room for prepends, a higher one for appends. With blockSize 1 the index must not be 0,
+// otherwise the tail would end with the lower char.
+func WithMiddleIndex(i int) Option {
+	return func(l *Lexid) error {
+		if i < 0 || i >= len(l.chars) {
+			return fmt.Errorf("%w: middle index (%d) must be in [0, %d)", ErrInvalidConfig, i, len(l.chars))
+		}
+		if i == 0 && l.blockSize == 1 {
+			return fmt.Errorf("%w: middle index must not be 0 for blockSize 1", ErrInvalidConfig)
+		}
+		l.middleIndex = i
+		return nil
+	}
+}
+
+// WithPadChar checks that c is the pad char of the alphabet, the min char.
+// Padding with any other char breaks the order of padded IDs, so the pad char can't be changed and other chars are rejected.
+func WithPadChar(c byte) Option {
+	return func(l *Lexid) error {
+		if c != l.lower {
+			return fmt.Errorf("%w: pad char '%c' must be the min char of the alphabet '%c'", ErrInvalidConfig, c, l.lower)
+		}
+		return nil
+	}
+}
+
+// PadChar returns the char used to pad IDs: the min char of the alphabet
+func (l Lexid) PadChar() byte {
+	return l.lower
+}
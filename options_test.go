@@ -0,0 +1,239 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxLength(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := New(CharsAlphanumericLower, 3, 1, WithMaxLength(4))
+		assert.Error(t, err)
+		_, err = New(CharsAlphanumericLower, 3, 1, WithMaxLength(0))
+		assert.Error(t, err)
+	})
+	lid := Must(CharsAlphanumericLower, 3, 1, WithMaxLength(6))
+	t.Run("next", func(t *testing.T) {
+		next, err := lid.NextErr("zzz")
+		require.NoError(t, err)
+		assert.Equal(t, "zzz002", next)
+		_, err = lid.NextErr("zzzzzz")
+		assert.Error(t, err)
+		assert.Equal(t, "zzzzzz002", lid.Next("zzzzzz"))
+	})
+	t.Run("prev", func(t *testing.T) {
+		prev, err := lid.PrevErr("001")
+		require.NoError(t, err)
+		assert.Equal(t, "000zzz", prev)
+		_, err = lid.PrevErr("000001")
+		assert.Error(t, err)
+	})
+	t.Run("next before", func(t *testing.T) {
+		next, err := lid.NextBefore("001", "002")
+		require.NoError(t, err)
+		assert.Len(t, next, 6)
+		_, err = lid.NextBefore("001001", "001002")
+		assert.Error(t, err)
+		_, err = lid.NextBeforeN("001", "002", 10)
+		require.NoError(t, err)
+		_, err = lid.NextBeforeN("001", "002", 50000)
+		assert.Error(t, err)
+	})
+}
+
+func TestWithMinLength(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := New(CharsAlphanumericLower, 3, 1, WithMinLength(4))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = New(CharsAlphanumericLower, 3, 1, WithMinLength(0))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = New(CharsAlphanumericLower, 3, 1, WithMaxLength(3), WithMinLength(6))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = New(CharsAlphanumericLower, 3, 1, WithMinLength(6), WithMaxLength(3))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+	lid := Must(CharsAlphanumericLower, 3, 10, WithMinLength(6))
+	t.Run("next", func(t *testing.T) {
+		assert.Equal(t, "00000b", lid.Next(""))
+		assert.Equal(t, "00100a", lid.Next("001"))
+		assert.Equal(t, lid.Next(""), string(lid.NextBytes(nil)))
+		assert.Equal(t, lid.Next("001"), string(lid.NextBytes([]byte("001"))))
+		var prev string
+		for i := 0; i < 10000; i++ {
+			next := lid.Next(prev)
+			assert.GreaterOrEqual(t, len(next), 6)
+			assert.Greater(t, next, prev)
+			prev = next
+		}
+	})
+	t.Run("prev", func(t *testing.T) {
+		assert.Len(t, lid.Prev(""), 9)
+		assert.Equal(t, "002zzq", lid.Prev("003"))
+		next := "zzz"
+		for i := 0; i < 10000; i++ {
+			prev := lid.Prev(next)
+			assert.GreaterOrEqual(t, len(prev), 6)
+			assert.Less(t, prev, next)
+			next = prev
+		}
+		bs1 := Must(CharsAlphanumericLower, 1, 1, WithMinLength(2))
+		assert.Equal(t, "1z", bs1.Prev("2"))
+	})
+	t.Run("between", func(t *testing.T) {
+		for _, tc := range [][2]string{{"", "001"}, {"001", "002"}, {"001", "zzz"}, {"", "zzzzzz"}, {"001", "001001"}} {
+			next, err := lid.NextBefore(tc[0], tc[1])
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, len(next), 6, tc)
+			assert.Greater(t, next, tc[0])
+			assert.Less(t, next, tc[1])
+			ids, err := lid.NextBeforeN(tc[0], tc[1], 3)
+			require.NoError(t, err)
+			for _, id := range ids {
+				assert.GreaterOrEqual(t, len(id), 6, tc)
+			}
+			mid, err := lid.MidpointBefore(tc[0], tc[1])
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, len(mid), 6, tc)
+		}
+	})
+	t.Run("middle and spread", func(t *testing.T) {
+		assert.Equal(t, "i01001", lid.Middle())
+		for _, id := range lid.Spread(10) {
+			assert.Len(t, id, 6)
+		}
+	})
+}
+
+func TestWithStrictPrev(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1, WithStrictPrev(true))
+	prev, err := lid.PrevErr("002")
+	require.NoError(t, err)
+	assert.Equal(t, "001", prev)
+	_, err = lid.PrevErr("001")
+	assert.ErrorIs(t, err, ErrExhausted)
+	_, err = lid.PrevErr("")
+	assert.ErrorIs(t, err, ErrExhausted)
+	// Prev and the default behavior stay lenient
+	assert.Equal(t, "000zzz", lid.Prev("001"))
+	prev, err = Must(CharsAlphanumericLower, 3, 1).PrevErr("001")
+	require.NoError(t, err)
+	assert.Equal(t, "000zzz", prev)
+}
+
+func TestWithAllowTrailingMin(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 1, WithAllowTrailingMin(true))
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, lid.Validate("aa0"))
+		assert.True(t, lid.IsValid("000"))
+		assert.False(t, Must(CharsAlphanumericLower, 3, 1).IsValid("aa0"))
+	})
+	t.Run("next", func(t *testing.T) {
+		assert.Equal(t, "002", lid.Next(""))
+		assert.Equal(t, "010", lid.Next("00z"))
+		next, err := lid.NextFixed("zzy")
+		require.NoError(t, err)
+		assert.Equal(t, "zzz", next)
+		assert.Equal(t, "zzz002", lid.Next("zzz"))
+		ids := lid.GenerateAfter("", 36*36-1)
+		assert.True(t, sort.StringsAreSorted(ids))
+		assert.Equal(t, "100", ids[36*36-2])
+	})
+	t.Run("next before", func(t *testing.T) {
+		next, err := lid.NextBefore("a9z", "aa9")
+		require.NoError(t, err)
+		assert.Equal(t, "aa0", next)
+		mid, err := lid.MidpointBefore("a9z", "aa1")
+		require.NoError(t, err)
+		assert.Equal(t, "aa0", mid)
+		assert.Equal(t, int64(1), lid.CountBetween("a9z", "aa1").Int64())
+		assert.False(t, lid.Adjacent("a9z", "aa1"))
+	})
+	t.Run("prev", func(t *testing.T) {
+		assert.Equal(t, "aa0", lid.Prev("aa1"))
+		assert.Equal(t, "a9z", lid.Prev("aa0"))
+		assert.Equal(t, "000", lid.Prev("001"))
+		// nothing sorts before the lower chars only
+		assert.Equal(t, "", lid.Prev("000"))
+		_, err := lid.PrevErr("000")
+		assert.ErrorIs(t, err, ErrExhausted)
+		assert.Equal(t, "000", Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 5, WithAllowTrailingMin(true)).Prev("002"))
+		// without the option Prev appends the padding block
+		assert.Equal(t, "000zzz", Must(CharsAlphanumericLower, 3, 1).Prev("001"))
+	})
+}
+
+func TestWithEmptyStart(t *testing.T) {
+	chars := "0123456789abcdefghijklmnopqrstuvwxyz"
+	t.Run("min", func(t *testing.T) {
+		lid := Must(chars, 3, 1, WithEmptyStart(StartMin))
+		assert.Equal(t, Must(chars, 3, 1).Next(""), lid.Next(""))
+		assert.Equal(t, "002", lid.Next(""))
+	})
+	t.Run("middle", func(t *testing.T) {
+		lid := Must(chars, 3, 1, WithEmptyStart(StartMiddle))
+		assert.Equal(t, "i01", lid.Middle())
+		assert.Equal(t, "i02", lid.Next(""))
+		assert.Equal(t, "hzz", lid.Prev(""))
+		assert.Equal(t, []byte("i02"), lid.NextBytes(nil))
+		assert.Equal(t, lid.Next(""), lid.NewGenerator("").Next())
+		sg := Must(chars, 3, 1, WithEmptyStart(StartMiddle), WithShortGreater(true))
+		assert.Greater(t, sg.Next(""), sg.Middle())
+	})
+	t.Run("literal", func(t *testing.T) {
+		lid := Must(chars, 3, 1, WithEmptyStart(StartAt("abc")))
+		assert.Equal(t, "abd", lid.Next(""))
+		assert.Equal(t, "abb", lid.Prev(""))
+		ns := lid.WithNamespace("ns1")
+		assert.Equal(t, "ns1abd", ns.Next(""))
+	})
+	t.Run("invalid literal", func(t *testing.T) {
+		for _, id := range []string{"ab", "ABC", "ab0"} {
+			_, err := New(chars, 3, 1, WithEmptyStart(StartAt(id)))
+			assert.ErrorIs(t, err, ErrInvalidConfig, id)
+		}
+	})
+}
+
+func TestWithMiddleIndex(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for _, i := range []int{-1, 36, 100} {
+			_, err := New(CharsAlphanumericLower, 3, 1, WithMiddleIndex(i))
+			assert.ErrorIs(t, err, ErrInvalidConfig, i)
+		}
+		_, err := New(CharsAlphanumericLower, 1, 1, WithMiddleIndex(0))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+	t.Run("default", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1, WithMiddleIndex(18))
+		assert.Equal(t, Must(CharsAlphanumericLower, 3, 1).Middle(), lid.Middle())
+	})
+	t.Run("biased", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1, WithMiddleIndex(5))
+		assert.Equal(t, "501", lid.Middle())
+		next, err := lid.NextBefore("001", "002")
+		require.NoError(t, err)
+		assert.Equal(t, "001501", next)
+		lid = Must(CharsAlphanumericLower, 3, 1, WithMiddleIndex(0))
+		assert.Equal(t, "001", lid.Middle())
+		assert.True(t, lid.IsValid(lid.Middle()))
+	})
+}
+
+func TestWithPadChar(t *testing.T) {
+	lid, err := New(CharsAlphanumericLower, 3, 1, WithPadChar('0'))
+	require.NoError(t, err)
+	assert.Equal(t, byte('0'), lid.PadChar())
+	assert.Equal(t, byte('-'), Must(CharsBase64, 3, 1).PadChar())
+	for _, c := range []byte{'a', 'z', '-', 0} {
+		_, err = New(CharsAlphanumericLower, 3, 1, WithPadChar(c))
+		assert.ErrorIs(t, err, ErrInvalidConfig, c)
+	}
+	ordered, err := NewOrdered("zyx0", 1, 1, WithPadChar('z'))
+	require.NoError(t, err)
+	assert.Equal(t, byte('z'), ordered.PadChar())
+	_, err = NewOrdered("zyx0", 1, 1, WithPadChar('0'))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
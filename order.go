@@ -0,0 +1,70 @@
+package lexid
+
+import "sort"
+
+// NewOrdered creates a Lexid like New, but keeps the order of chars as the ordering of IDs instead of sorting them by bytes.
+// Produced IDs must be compared with OrderLess, or transliterated with Encode into a byte-sorted form for storage.
+func NewOrdered(chars string, blockSize, stepSize int, opts ...Option) (*Lexid, error) {
+	return newLexid(chars, blockSize, stepSize, false, opts...)
+}
+
+// OrderLess reports whether a sorts before b by the alphabet rank of chars rather than by raw bytes.
+// Plain string comparison matches this order only for byte-sorted alphabets, which is always the case for New.
+func (l Lexid) OrderLess(a, b string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if ia, ib := l.charIndex[a[i]], l.charIndex[b[i]]; ia != ib {
+			return ia < ib
+		}
+	}
+	return len(a) < len(b)
+}
+
+// Encode transliterates the id into the byte-sorted alphabet, so plain comparison of encoded IDs matches OrderLess.
+// It returns the id unchanged for byte-sorted alphabets.
+func (l Lexid) Encode(id string) string {
+	if !l.ordered {
+		return id
+	}
+	res := []byte(id)
+	for i, c := range res {
+		res[i] = l.encodeChar[c]
+	}
+	return string(res)
+}
+
+// Decode transliterates the id encoded with Encode back into the alphabet
+func (l Lexid) Decode(encoded string) string {
+	if !l.ordered {
+		return encoded
+	}
+	res := []byte(encoded)
+	for i, c := range res {
+		res[i] = l.decodeChar[c]
+	}
+	return string(res)
+}
+
+// initEncoding initializes the transliteration between the alphabet order and the byte order of the same chars
+func (l *Lexid) initEncoding() {
+	sorted := append([]byte(nil), l.chars...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	for i := range l.encodeChar {
+		l.encodeChar[i] = byte(i)
+		l.decodeChar[i] = byte(i)
+	}
+	for i, c := range l.chars {
+		l.encodeChar[c] = sorted[i]
+		l.decodeChar[sorted[i]] = c
+	}
+	l.ordered = true
+}
+
+// less compares ids by the alphabet order
+func (l Lexid) less(a, b string) bool {
+	if !l.ordered {
+		return a < b
+	}
+	return l.OrderLess(a, b)
+}
@@ -0,0 +1,69 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_OrderLess(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	assert.True(t, lid.OrderLess("001", "002"))
+	assert.False(t, lid.OrderLess("002", "001"))
+	assert.False(t, lid.OrderLess("001", "001"))
+	assert.True(t, lid.OrderLess("001", "001001"))
+	assert.False(t, lid.OrderLess("001001", "001"))
+
+	var ids []string
+	var prev string
+	for i := 0; i < 1000; i++ {
+		prev = lid.Next(prev)
+		ids = append(ids, prev, prev+"i01")
+	}
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	sort.Slice(ids, func(i, j int) bool {
+		return lid.OrderLess(ids[i], ids[j])
+	})
+	assert.Equal(t, sorted, ids)
+}
+
+func TestNewOrdered(t *testing.T) {
+	// digits sort after letters in this collation
+	lid, err := NewOrdered("abcdefghijklmnopqrstuvwxyz0123456789", 3, 10)
+	require.NoError(t, err)
+	t.Run("next", func(t *testing.T) {
+		assert.Equal(t, "aal", lid.Next(""))
+		var prev string
+		for i := 0; i < 10000; i++ {
+			next := lid.Next(prev)
+			if prev != "" {
+				assert.True(t, lid.OrderLess(prev, next), next)
+				assert.Greater(t, lid.Encode(next), lid.Encode(prev))
+			}
+			assert.Equal(t, next, lid.Decode(lid.Encode(next)))
+			prev = next
+		}
+	})
+	t.Run("next before", func(t *testing.T) {
+		next, err := lid.NextBefore("aaz", "ab0")
+		require.NoError(t, err)
+		assert.True(t, lid.OrderLess("aaz", next))
+		assert.True(t, lid.OrderLess(next, "ab0"))
+		_, err = lid.NextBefore("ab0", "aaz")
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+	t.Run("encode", func(t *testing.T) {
+		assert.Equal(t, "001", lid.Encode("aab"))
+		assert.Equal(t, "aab", lid.Decode("001"))
+		sorted := Must(CharsAlphanumericLower, 3, 10)
+		assert.Equal(t, "a0b", sorted.Encode("a0b"))
+		assert.Equal(t, "a0b", sorted.Decode("a0b"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewOrdered("a", 3, 1)
+		assert.ErrorIs(t, err, ErrInvalidChars)
+	})
+}
@@ -0,0 +1,113 @@
+package lexid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// pbidValidator holds the *Lexid validating PBID values, nil disables the validation
+var pbidValidator atomic.Value
+
+// SetPBIDValidator sets the Lexid used to validate PBID values on marshaling and unmarshaling, nil disables the validation.
+// It's safe to call concurrently with marshaling.
+func SetPBIDValidator(l *Lexid) {
+	pbidValidator.Store(l)
+}
+
+// PBID is an ID usable as a gogo/protobuf custom type of a bytes or string field:
+//
+//	bytes id = 1 [(gogoproto.customtype) = "github.com/anyproto/lexid.PBID", (gogoproto.nullable) = false];
+//
+// The empty PBID is an unset field and is never validated.
+type PBID string
+
+// validate checks the id with the validator set by SetPBIDValidator
+func (id PBID) validate() error {
+	v := loadValidator(&pbidValidator)
+	if id == "" || v == nil {
+		return nil
+	}
+	return v.Validate(string(id))
+}
+
+// Marshal returns the wire representation of the id
+func (id PBID) Marshal() ([]byte, error) {
+	if err := id.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(id), nil
+}
+
+// MarshalTo writes the id to data, data must be at least Size bytes
+func (id PBID) MarshalTo(data []byte) (n int, err error) {
+	if err = id.validate(); err != nil {
+		return 0, err
+	}
+	if len(data) < len(id) {
+		return 0, fmt.Errorf("%w: buffer of %d bytes is too small for id of %d bytes", io.ErrShortBuffer, len(data), len(id))
+	}
+	return copy(data, id), nil
+}
+
+// Unmarshal sets the id from the wire representation
+func (id *PBID) Unmarshal(data []byte) error {
+	v := PBID(data)
+	if err := v.validate(); err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// Size returns the length of the wire representation
+func (id PBID) Size() int {
+	return len(id)
+}
+
+// MarshalJSON encodes the id as a JSON string
+func (id PBID) MarshalJSON() ([]byte, error) {
+	if err := id.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON decodes the id from a JSON string
+func (id *PBID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.Unmarshal([]byte(s))
+}
+
+// Equal reports whether the ids are equal
+func (id PBID) Equal(other PBID) bool {
+	return id == other
+}
+
+// Compare returns -1, 0 or 1 if the id is less, equal or greater than the other id
+func (id PBID) Compare(other PBID) int {
+	switch {
+	case id < other:
+		return -1
+	case id > other:
+		return 1
+	}
+	return 0
+}
+
+// NewPopulatedPBID returns a random id for tests generated by gogo/protobuf
+func NewPopulatedPBID(r interface{ Intn(n int) int }) *PBID {
+	const chars = "0123456789abcdefghijklmnopqrstuvwxyz"
+	id := make([]byte, r.Intn(10)+1)
+	for i := range id {
+		id[i] = chars[r.Intn(len(chars))]
+	}
+	// the lower char can't be the last one
+	id[len(id)-1] = chars[r.Intn(len(chars)-1)+1]
+	pbid := PBID(id)
+	return &pbid
+}
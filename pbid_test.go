@@ -0,0 +1,100 @@
+package lexid
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPBID(t *testing.T) {
+	t.Run("without validator", func(t *testing.T) {
+		id := PBID("anything")
+		data, err := id.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, []byte("anything"), data)
+		assert.Equal(t, 8, id.Size())
+		buf := make([]byte, id.Size())
+		n, err := id.MarshalTo(buf)
+		require.NoError(t, err)
+		assert.Equal(t, 8, n)
+		_, err = id.MarshalTo(buf[:2])
+		assert.Error(t, err)
+		var res PBID
+		require.NoError(t, res.Unmarshal(data))
+		assert.True(t, res.Equal(id))
+	})
+	t.Run("with validator", func(t *testing.T) {
+		SetPBIDValidator(Must(CharsAlphanumericLower, 3, 1))
+		defer SetPBIDValidator(nil)
+		_, err := PBID("00A").Marshal()
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = PBID("010").MarshalTo(make([]byte, 3))
+		assert.ErrorIs(t, err, ErrInvalidID)
+		var res PBID
+		assert.ErrorIs(t, res.Unmarshal([]byte("01")), ErrInvalidID)
+		assert.Equal(t, PBID(""), res)
+		require.NoError(t, res.Unmarshal([]byte("001")))
+		assert.Equal(t, PBID("001"), res)
+		// the unset field is valid
+		require.NoError(t, res.Unmarshal(nil))
+		_, err = PBID("").Marshal()
+		assert.NoError(t, err)
+	})
+	t.Run("json", func(t *testing.T) {
+		SetPBIDValidator(Must(CharsAlphanumericLower, 3, 1))
+		defer SetPBIDValidator(nil)
+		data, err := json.Marshal(struct{ ID PBID }{"001"})
+		require.NoError(t, err)
+		assert.Equal(t, `{"ID":"001"}`, string(data))
+		var v struct{ ID PBID }
+		require.NoError(t, json.Unmarshal(data, &v))
+		assert.Equal(t, PBID("001"), v.ID)
+		assert.Error(t, json.Unmarshal([]byte(`{"ID":"000"}`), &v))
+		_, err = json.Marshal(struct{ ID PBID }{"0"})
+		assert.Error(t, err)
+	})
+	t.Run("compare", func(t *testing.T) {
+		assert.Equal(t, -1, PBID("001").Compare("002"))
+		assert.Equal(t, 0, PBID("001").Compare("001"))
+		assert.Equal(t, 1, PBID("002").Compare("001"))
+	})
+	t.Run("populated", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 1, 1)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			assert.True(t, lid.IsValid(string(*NewPopulatedPBID(r))))
+		}
+	})
+}
+
+func TestSetPBIDValidator(t *testing.T) {
+	three, two := Must(CharsAlphanumericLower, 3, 1), Must(CharsAlphanumericLower, 2, 1)
+	SetPBIDValidator(three)
+	defer SetPBIDValidator(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				SetPBIDValidator(two)
+				SetPBIDValidator(three)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				var id PBID
+				// valid for both validators
+				assert.NoError(t, id.Unmarshal([]byte("001001")))
+				// valid for none of them, so a missing validator is never seen
+				assert.ErrorIs(t, id.Unmarshal([]byte("00100")), ErrInvalidID)
+			}
+		}()
+	}
+	wg.Wait()
+}
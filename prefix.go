@@ -0,0 +1,163 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PrefixRange returns the bounds of all IDs that start with the prefix: start is inclusive and end is exclusive.
+// The start is the prefix itself, because no ID with the prefix sorts before it.
+// The end is the successor of the prefix; it's empty if the prefix consists of upper chars only and has no successor.
+func (l Lexid) PrefixRange(prefix string) (start, end string, err error) {
+	if err = l.checkChars(prefix); err != nil {
+		return "", "", err
+	}
+	end, _ = l.prefixSuccessor(prefix)
+	return prefix, end, nil
+}
+
+// AfterPrefix returns the successor of the prefix padded to a whole block: the smallest ID of that length
+// sorting after every ID starting with the prefix. A longer ID may sort between them,
+// for example "b001" is before "b1" returned for the prefix "a" with blockSize 2.
+// A prefix of upper chars only has no such ID, because every greater ID starts with it, so the result is empty.
+func (l Lexid) AfterPrefix(prefix string) string {
+	succ, ok := l.prefixSuccessor(prefix)
+	if !ok {
+		return ""
+	}
+	if pad := l.blockSize - (len(succ) % l.blockSize); pad != l.blockSize {
+		succ = l.padding(succ, pad)
+	}
+	return succ
+}
+
+// GreatestBelow returns the greatest valid ID that sorts strictly before the target and has at most maxBlocks blocks,
+// the namespace included: a key as close to a ceiling as the width budget allows. It's the dual of AfterPrefix.
+// The target may be any string of the alphabet chars. ErrExhausted is returned if no ID fits below the target.
+// Generators created with WithShortGreater are not supported.
+func (l Lexid) GreatestBelow(target string, maxBlocks int) (string, error) {
+	if l.shortGreater {
+		return "", fmt.Errorf("%w: GreatestBelow doesn't support the short greater ordering", ErrInvalidConfig)
+	}
+	if maxBlocks < 1 {
+		return "", fmt.Errorf("%w: maxBlocks (%d) must be positive", ErrInvalidConfig, maxBlocks)
+	}
+	if target == "" {
+		return "", fmt.Errorf("%w: empty target", ErrInvalidID)
+	}
+	tail, err := l.trimNamespace(target)
+	if err != nil {
+		return "", err
+	}
+	if err = l.checkChars(tail); err != nil {
+		return "", err
+	}
+	maxLength := maxBlocks * l.blockSize
+	if l.maxLength > 0 && l.maxLength < maxLength {
+		maxLength = l.maxLength
+	}
+	// lengths of the tail, the namespace counts towards the min and max lengths
+	r := l.withoutNamespace()
+	if r.minLength -= len(l.namespace); r.minLength < 0 {
+		r.minLength = 0
+	}
+	maxLength -= len(l.namespace)
+	// a shorter ID may be greater than every longer one, for example a prefix of the target, so every length is checked
+	var best string
+	for length := r.minIDLength(); length <= maxLength; length += l.blockSize {
+		if id := r.greatestBelowAt(tail, length); id != "" && (best == "" || r.less(best, id)) {
+			best = id
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("%w: no id below '%s' fits in %d blocks", ErrExhausted, target, maxBlocks)
+	}
+	return l.namespace + best, nil
+}
+
+// greatestBelowAt returns the greatest valid ID of the given length that sorts before the target, or "" if there is none
+func (l Lexid) greatestBelowAt(target string, length int) string {
+	var v *big.Int
+	if len(target) > length {
+		// the truncated target is a prefix of the target, so it sorts before it
+		v = l.toInt(target[:length])
+	} else {
+		// the target padded with lower chars sorts after or equal the target
+		v = l.toIntLength(target, length)
+		v.Sub(v, big.NewInt(1))
+	}
+	if !l.trailingMin && v.Sign() > 0 && new(big.Int).Rem(v, big.NewInt(int64(len(l.chars)))).Sign() == 0 {
+		v.Sub(v, big.NewInt(1))
+	}
+	if v.Sign() < 0 || (!l.trailingMin && v.Sign() == 0) {
+		return ""
+	}
+	return l.fromInt(v, length)
+}
+
+// UpperBound returns the smallest valid ID one block longer than the aligned id that sorts after every ID starting with the id,
+// for example "abd001" for "abc": the id with the last char bumped, carrying over upper chars, and the min block appended.
+// Unlike AfterPrefix the result is always longer than the id. A prefix of upper chars only has no such ID, so the result is empty.
+func (l Lexid) UpperBound(id string) string {
+	succ, ok := l.prefixSuccessor(id)
+	if !ok {
+		return ""
+	}
+	for len(succ) < len(id) || len(succ)%l.blockSize != 0 {
+		succ += string(l.lower)
+	}
+	return l.padding(succ, l.blockSize)
+}
+
+// CommonPrefix returns the longest block-aligned prefix shared by a and b
+func (l Lexid) CommonPrefix(a, b string) string {
+	var n int
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	n -= n % l.blockSize
+	return a[:n]
+}
+
+// NextInPrefix returns the next ID after prev that starts with the prefix, for compound keys like tenant followed by a sequence.
+// Only the suffix after the prefix is advanced and it keeps its length: the empty suffix starts with Next("")
+// and ErrExhausted is returned when the suffix can't be advanced without growing. The prefix may contain any chars.
+func (l Lexid) NextInPrefix(prev, prefix string) (string, error) {
+	if prev != "" && !strings.HasPrefix(prev, prefix) {
+		return "", fmt.Errorf("%w: '%s' doesn't start with the prefix '%s'", ErrInvalidID, prev, prefix)
+	}
+	suffix := strings.TrimPrefix(prev, prefix)
+	if err := l.checkChars(suffix); err != nil {
+		return "", err
+	}
+	next, err := l.NextFixed(suffix)
+	if err != nil {
+		return "", fmt.Errorf("%w: prefix '%s' space is exhausted", err, prefix)
+	}
+	next = prefix + next
+	if err = l.checkMaxLength(next); err != nil {
+		return "", err
+	}
+	return next, nil
+}
+
+// prefixSuccessor returns the smallest string which is greater than every string starting with the prefix
+func (l Lexid) prefixSuccessor(prefix string) (string, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != l.upper {
+			return prefix[:i] + string(l.nextChar[prefix[i]]), true
+		}
+	}
+	return "", false
+}
+
+// checkChars returns an error if s contains chars not in the alphabet
+func (l Lexid) checkChars(s string) error {
+	for i := 0; i < len(s); i++ {
+		if l.charIndex[s[i]] == -1 {
+			return fmt.Errorf("%w: '%s' contains the char '%c' not in the alphabet", ErrInvalidID, s, s[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package lexid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_PrefixRange(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("range", func(t *testing.T) {
+		start, end, err := lid.PrefixRange("abc")
+		require.NoError(t, err)
+		assert.Equal(t, "abc", start)
+		assert.Equal(t, "abd", end)
+		for _, id := range []string{"abc", "abc000zzz", "abc001", "abczzzzzz"} {
+			assert.True(t, id >= start && id < end, id)
+		}
+		for _, id := range []string{"abb", "abbzzz", "abd", "abd001"} {
+			assert.False(t, id >= start && id < end, id)
+		}
+	})
+	t.Run("upper chars", func(t *testing.T) {
+		start, end, err := lid.PrefixRange("abz")
+		require.NoError(t, err)
+		assert.Equal(t, "abz", start)
+		assert.Equal(t, "ac", end)
+		start, end, err = lid.PrefixRange("zzz")
+		require.NoError(t, err)
+		assert.Equal(t, "zzz", start)
+		assert.Equal(t, "", end)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := lid.PrefixRange("aB")
+		assert.Error(t, err)
+	})
+}
+
+func TestLexid_CommonPrefix(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("block aligned", func(t *testing.T) {
+		assert.Equal(t, "abc", lid.CommonPrefix("abc001", "abc002"))
+		assert.Equal(t, "abc", lid.CommonPrefix("abcde1", "abcdf1"))
+		assert.Equal(t, "abc", lid.CommonPrefix("abc", "abc001"))
+		assert.Equal(t, "abc001", lid.CommonPrefix("abc001002", "abc001003"))
+	})
+	t.Run("no common prefix", func(t *testing.T) {
+		assert.Equal(t, "", lid.CommonPrefix("abc", "xyz"))
+		assert.Equal(t, "", lid.CommonPrefix("abc", "abd"))
+		assert.Equal(t, "", lid.CommonPrefix("", "abd"))
+	})
+	t.Run("identical", func(t *testing.T) {
+		assert.Equal(t, "abc001", lid.CommonPrefix("abc001", "abc001"))
+	})
+}
+
+func TestLexid_AfterPrefix(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.Equal(t, "abd", lid.AfterPrefix("abc"))
+	assert.Equal(t, "ac1", lid.AfterPrefix("abz"))
+	assert.Equal(t, "ac1", lid.AfterPrefix("ab"))
+	assert.Equal(t, "abc002", lid.AfterPrefix("abc001"))
+	assert.Equal(t, "", lid.AfterPrefix("zzz"))
+	assert.Equal(t, "", lid.AfterPrefix(""))
+	for _, prefix := range []string{"abc", "abz", "ab", "a0z"} {
+		after := lid.AfterPrefix(prefix)
+		for _, id := range []string{prefix, prefix + "zzz", prefix + "zzzzzzzzz", prefix + "001"} {
+			assert.Greater(t, after, id)
+		}
+		assert.Zero(t, len(after)%3)
+	}
+}
+
+func TestLexid_GreatestBelow(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("below", func(t *testing.T) {
+		for _, c := range []struct {
+			target    string
+			maxBlocks int
+			expected  string
+		}{
+			{"abc", 1, "abb"},
+			{"abd", 2, "abczzz"},
+			{"abd", 1, "abc"},
+			{"ab", 1, "aaz"},
+			// the prefix of the target is greater than any longer ID below it
+			{"abc001", 2, "abc"},
+			{"abc002", 2, "abc001"},
+			{"abc0015", 3, "abc0014zz"},
+			{"001", 2, "000zzz"},
+		} {
+			id, err := lid.GreatestBelow(c.target, c.maxBlocks)
+			require.NoError(t, err, c.target)
+			assert.Equal(t, c.expected, id, c.target)
+			assert.True(t, lid.IsValid(id))
+		}
+	})
+	t.Run("exhausted", func(t *testing.T) {
+		_, err := lid.GreatestBelow("001", 1)
+		assert.ErrorIs(t, err, ErrExhausted)
+		_, err = lid.GreatestBelow("000", 3)
+		assert.ErrorIs(t, err, ErrExhausted)
+	})
+	t.Run("ordered", func(t *testing.T) {
+		ordered, err := NewOrdered("210", 1, 1)
+		require.NoError(t, err)
+		// "10" is byte-wise greater than "0" but sorts before it in the order of chars
+		id, err := ordered.GreatestBelow("01", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "0", id)
+		assert.True(t, ordered.OrderLess(id, "01"))
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ns := lid.WithNamespace("abc")
+		id, err := ns.GreatestBelow("abci00", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "abchzz", id)
+		_, err = ns.GreatestBelow("abci00", 1)
+		assert.ErrorIs(t, err, ErrExhausted)
+		_, err = ns.GreatestBelow("abdi00", 2)
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := lid.GreatestBelow("abc", 0)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = lid.GreatestBelow("", 1)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.GreatestBelow("ab-", 1)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = Must(CharsAlphanumericLower, 3, 1, WithShortGreater(true)).GreatestBelow("abc", 1)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestLexid_NextInPrefix(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 2, 10)
+	next, err := lid.NextInPrefix("", "tenant|")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant|0b", next)
+	next, err = lid.NextInPrefix("tenant|", "tenant|")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant|0b", next)
+	prev := next
+	for i := 0; i < 100; i++ {
+		next, err = lid.NextInPrefix(prev, "tenant|")
+		require.NoError(t, err)
+		assert.Greater(t, next, prev)
+		assert.Len(t, next, len(prev))
+		prev = next
+	}
+	t.Run("exhausted", func(t *testing.T) {
+		_, err := lid.NextInPrefix("abzz", "ab")
+		assert.ErrorIs(t, err, ErrExhausted)
+		next, err := lid.NextInPrefix("abzzzz01", "abzzzz")
+		require.NoError(t, err)
+		assert.Equal(t, "abzzzz0b", next)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := lid.NextInPrefix("ac01", "ab")
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = lid.NextInPrefix("ab0-", "ab")
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+func TestLexid_UpperBound(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.Equal(t, "abd001", lid.UpperBound("abc"))
+	assert.Equal(t, "ac0001", lid.UpperBound("abz"))
+	assert.Equal(t, "b00001", lid.UpperBound("azz"))
+	assert.Equal(t, "ac0001", lid.UpperBound("ab"))
+	assert.Equal(t, "abczz1000001", lid.UpperBound("abczz0zzz"))
+	assert.Equal(t, "", lid.UpperBound("zzz"))
+	for _, id := range []string{"abc", "abz", "01", "00z", "abc00z"} {
+		upper := lid.UpperBound(id)
+		assert.True(t, lid.IsValid(upper), upper)
+		assert.Greater(t, len(upper), len(id))
+		for _, ext := range []string{id, id + "zzz", id + "zzzzzz", lid.Next(id), lid.Prev(id + "zzz")} {
+			if strings.HasPrefix(ext, id) {
+				assert.Less(t, ext, upper, ext)
+			}
+		}
+		// nothing of the same length between the extensions and the bound
+		maxExt := id
+		for len(maxExt) < len(upper) {
+			maxExt += "z"
+		}
+		assert.True(t, lid.Adjacent(maxExt, upper), id)
+	}
+}
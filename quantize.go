@@ -0,0 +1,40 @@
+package lexid
+
+import "strings"
+
+// Quantize maps every byte of s that is not in the alphabet to the alphabet char with the nearest byte value,
+// the lower one on ties, and pads the result to a valid ID, so it sorts close to the intended position of s.
+// It's lossy: different strings may map to the same ID, so use it only to salvage imported data during migration.
+func (l Lexid) Quantize(s string) string {
+	if l.namespace != "" {
+		return l.namespace + l.withoutNamespace().Quantize(strings.TrimPrefix(s, l.namespace))
+	}
+	res := []byte(l.padMinLength(s))
+	for i, c := range res {
+		if l.charIndex[c] == -1 {
+			res[i] = l.nearestChar(c)
+		}
+	}
+	if pad := l.blockSize - len(res)%l.blockSize; pad != l.blockSize {
+		res = l.appendPadding(res, pad)
+	} else if len(res) == 0 || res[len(res)-1] == l.lower {
+		// the padding keeps the ids with the prefix right after it
+		res = l.appendPadding(res, l.blockSize)
+	}
+	return string(res)
+}
+
+// nearestChar returns the alphabet char with the nearest byte value to c, the lower one on ties
+func (l Lexid) nearestChar(c byte) byte {
+	best, bestDist := l.chars[0], 256
+	for _, ch := range l.chars {
+		dist := int(ch) - int(c)
+		if dist < 0 {
+			dist = -dist
+		}
+		if dist < bestDist || dist == bestDist && ch < best {
+			best, bestDist = ch, dist
+		}
+	}
+	return best
+}
@@ -0,0 +1,38 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Quantize(t *testing.T) {
+	lid := Must("0123456789abcdef", 3, 10)
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"abc", "abc"},
+		{"", "001"},
+		// below the alphabet
+		{"!#a", "00a"},
+		// between '9' and 'a', ties go to the lower char
+		{":_M", "9a9"},
+		// above the alphabet
+		{"zz~", "fff"},
+		{"ab", "ab1"},
+		{"ab0", "ab0001"},
+		{"aZ", "aa1"},
+		{"a\x00c", "a0c"},
+	} {
+		assert.Equal(t, tc.out, lid.Quantize(tc.in), tc.in)
+		assert.True(t, lid.IsValid(lid.Quantize(tc.in)), tc.in)
+	}
+
+	// quantized keys keep the order of the keys in the alphabet
+	assert.Less(t, lid.Quantize("a9~"), lid.Quantize("ab"))
+	assert.Less(t, lid.Quantize("ab0"), lid.Quantize("ab1"))
+
+	ns := lid.WithNamespace("ab1")
+	assert.Equal(t, "ab1a9c", ns.Quantize("ab1a:c"))
+	assert.True(t, ns.IsValid(ns.Quantize("ab1")))
+}
@@ -0,0 +1,28 @@
+package lexid
+
+// Range returns an iterator over from and the following IDs generated with Next that are less than to.
+// The empty from starts with the first ID, Next(""). The signature is compatible with iter.Seq[string].
+func (l Lexid) Range(from, to string) func(yield func(string) bool) {
+	return l.rangeIDs(from, to, false)
+}
+
+// RangeInclusive is like Range, but also yields to if it's reached, like SQL BETWEEN.
+// If to is not on a step boundary of from, it's never reached and the iteration stops at the last ID less than to.
+func (l Lexid) RangeInclusive(from, to string) func(yield func(string) bool) {
+	return l.rangeIDs(from, to, true)
+}
+
+func (l Lexid) rangeIDs(from, to string, inclusive bool) func(yield func(string) bool) {
+	return func(yield func(string) bool) {
+		id := from
+		if id == "" {
+			id = l.Next("")
+		}
+		for l.less(id, to) || (inclusive && id == to) {
+			if !yield(id) || id == to {
+				return
+			}
+			id = l.Next(id)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Range(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	collect := func(seq func(yield func(string) bool)) []string {
+		var ids []string
+		seq(func(id string) bool {
+			ids = append(ids, id)
+			return true
+		})
+		return ids
+	}
+	ids := lid.GenerateAfter("", 5)
+	t.Run("exclusive", func(t *testing.T) {
+		assert.Equal(t, ids[:4], collect(lid.Range("", ids[4])))
+		assert.Equal(t, ids[1:4], collect(lid.Range(ids[1], ids[4])))
+		assert.Empty(t, collect(lid.Range(ids[1], ids[1])))
+		assert.Empty(t, collect(lid.Range(ids[2], ids[1])))
+	})
+	t.Run("inclusive", func(t *testing.T) {
+		assert.Equal(t, ids, collect(lid.RangeInclusive("", ids[4])))
+		assert.Equal(t, ids[1:2], collect(lid.RangeInclusive(ids[1], ids[1])))
+		// not on the step boundary
+		assert.Equal(t, ids[:4], collect(lid.RangeInclusive("", lid.NextOne(ids[3]))))
+		assert.Empty(t, collect(lid.RangeInclusive(ids[2], ids[1])))
+	})
+	t.Run("break", func(t *testing.T) {
+		var got []string
+		lid.Range("", "zzz")(func(id string) bool {
+			got = append(got, id)
+			return len(got) < 3
+		})
+		assert.Equal(t, ids[:3], got)
+	})
+	t.Run("growing", func(t *testing.T) {
+		got := collect(lid.Range("zzn", "zzz00z"))
+		assert.Equal(t, []string{"zzn", "zzx", "zzx00b"}, got[:3])
+		for i := 1; i < len(got); i++ {
+			assert.Equal(t, lid.Next(got[i-1]), got[i])
+		}
+		assert.Less(t, got[len(got)-1], "zzz00z")
+		assert.GreaterOrEqual(t, lid.Next(got[len(got)-1]), "zzz00z")
+	})
+}
@@ -0,0 +1,51 @@
+package lexid
+
+import "math/big"
+
+// Recommend suggests blockSize and stepSize for a workload of expectedItems IDs generated with Next
+// followed by about expectedInsertsPerGap NextBefore insertions into every gap between them.
+//
+// The heuristic: NextBefore takes at most 0.3 of the distance to the next ID, so a gap is split unevenly,
+// and a gap that has to fit k insertions spread across it needs about 16*(k+1) steps.
+// The blockSize is the smallest one whose block holds twice the steps of all items,
+// so the items and the insertions keep single block IDs and there is room to append more items.
+// If chars has less than two unique chars, it returns 0, 0.
+func Recommend(chars string, expectedItems, expectedInsertsPerGap int) (blockSize, stepSize int) {
+	base := uniqueCharsCount(chars)
+	if base < 2 {
+		return 0, 0
+	}
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if expectedInsertsPerGap < 0 {
+		expectedInsertsPerGap = 0
+	}
+	stepSize = 16 * (expectedInsertsPerGap + 1)
+	if expectedInsertsPerGap == 0 {
+		stepSize = 1
+	}
+	need := big.NewInt(int64(expectedItems) + 1)
+	need.Mul(need, big.NewInt(2*int64(stepSize)))
+	blockSize = 1
+	// valid ids of a block: base^(blockSize-1)*(base-1)
+	count := big.NewInt(int64(base - 1))
+	for count.Cmp(need) < 0 {
+		count.Mul(count, big.NewInt(int64(base)))
+		blockSize++
+	}
+	return blockSize, stepSize
+}
+
+// uniqueCharsCount returns the number of unique bytes in chars
+func uniqueCharsCount(chars string) int {
+	var seen [256]bool
+	var count int
+	for i := 0; i < len(chars); i++ {
+		if !seen[chars[i]] {
+			seen[chars[i]] = true
+			count++
+		}
+	}
+	return count
+}
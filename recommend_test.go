@@ -0,0 +1,61 @@
+package lexid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommend(t *testing.T) {
+	t.Run("invalid chars", func(t *testing.T) {
+		bs, ss := Recommend("aaa", 10, 10)
+		assert.Equal(t, 0, bs)
+		assert.Equal(t, 0, ss)
+	})
+	t.Run("no inserts", func(t *testing.T) {
+		bs, ss := Recommend(CharsAlphanumericLower, 1000, 0)
+		assert.Equal(t, 3, bs)
+		assert.Equal(t, 1, ss)
+	})
+	for _, tc := range []struct {
+		chars          string
+		items, inserts int
+	}{
+		{CharsAlphanumericLower, 10, 5},
+		{CharsAlphanumericLower, 1000, 10},
+		{CharsAlphanumeric, 100, 50},
+		{CharsBase64, 5000, 3},
+		{"01", 20, 8},
+	} {
+		t.Run(fmt.Sprintf("%d items %d inserts", tc.items, tc.inserts), func(t *testing.T) {
+			bs, ss := Recommend(tc.chars, tc.items, tc.inserts)
+			lid, err := New(tc.chars, bs, ss)
+			require.NoError(t, err)
+			ids := lid.GenerateAfter("", tc.items)
+			for _, id := range ids {
+				require.Len(t, id, bs)
+			}
+			// fill the first, the middle and the last gaps, every insert goes into the widest part of the gap
+			for _, g := range []int{0, len(ids) / 2, len(ids) - 2} {
+				if g < 0 {
+					continue
+				}
+				gap := []string{ids[g], ids[g+1]}
+				for i := 0; i < tc.inserts; i++ {
+					widest := 0
+					for j := 1; j < len(gap)-1; j++ {
+						if lid.CountBetween(gap[j], gap[j+1]).Cmp(lid.CountBetween(gap[widest], gap[widest+1])) > 0 {
+							widest = j
+						}
+					}
+					key, err := lid.NextBefore(gap[widest], gap[widest+1])
+					require.NoError(t, err)
+					require.Len(t, key, bs, "insert %d", i)
+					gap = append(gap[:widest+1], append([]string{key}, gap[widest+1:]...)...)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package lexid
+
+import "fmt"
+
+// redisLexSyntax contains bytes with a special meaning in the ZRANGEBYLEX range syntax
+const redisLexSyntax = "-+[("
+
+// ZLexMin returns the ZRANGEBYLEX bound that is less than or equal to any ID produced by Lexid
+func (l Lexid) ZLexMin() string {
+	return "-"
+}
+
+// ZLexMax returns the ZRANGEBYLEX bound that is greater than or equal to any ID produced by Lexid
+func (l Lexid) ZLexMax() string {
+	return "+"
+}
+
+// CheckRedisLex returns an error if the alphabet contains bytes used by the ZRANGEBYLEX range syntax ("-", "+", "[", "(").
+// IDs over such an alphabet may be mistaken for range bounds, e.g. the ID "-" is indistinguishable from ZLexMin.
+// CharsAlphanumeric, CharsAlphanumericLower and CharsBase58 are Redis-lex safe; CharsAll, CharsAllNoEscape and CharsBase64 are not.
+func (l Lexid) CheckRedisLex() error {
+	for i := 0; i < len(redisLexSyntax); i++ {
+		if l.charIndex[redisLexSyntax[i]] != -1 {
+			return fmt.Errorf("%w: '%c' is a part of the ZRANGEBYLEX range syntax", ErrInvalidChars, redisLexSyntax[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_ZLex(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.Equal(t, "-", lid.ZLexMin())
+	assert.Equal(t, "+", lid.ZLexMax())
+}
+
+func TestLexid_CheckRedisLex(t *testing.T) {
+	for _, chars := range []string{CharsAlphanumeric, CharsAlphanumericLower, CharsBase58, CharsUnambiguous} {
+		assert.NoError(t, Must(chars, 3, 1).CheckRedisLex(), chars)
+	}
+	for _, chars := range []string{CharsAll, CharsAllNoEscape, CharsBase64, "ab(", "ab["} {
+		assert.Error(t, Must(chars, 3, 1).CheckRedisLex(), chars)
+	}
+}
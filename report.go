@@ -0,0 +1,38 @@
+package lexid
+
+import "math/big"
+
+// Report describes how close a sorted list of IDs is to the keyspace exhaustion, see ExhaustionReport
+type Report struct {
+	// TightestGap is the index i of the gap between ids[i] and ids[i+1] with the least room, -1 for less than two IDs
+	TightestGap int
+	// MinDistance is the number of valid IDs fitting strictly in the tightest gap without growing the length, see CountBetween
+	MinDistance *big.Int
+	// MaxLength is the length of the longest ID
+	MaxLength int
+	// InsertsBeforeGrowth estimates how many IDs can be inserted one after another at the same place of the tightest gap
+	// before the length grows, when every insert splits the remaining room in half
+	InsertsBeforeGrowth int
+}
+
+// ExhaustionReport scans the sorted ids and reports the tightest gap between consecutive IDs and the max length.
+// The gaps are compared with exact distances, so the result can be used as metrics of the keyspace health.
+func (l Lexid) ExhaustionReport(ids []string) Report {
+	report := Report{TightestGap: -1, MinDistance: new(big.Int)}
+	for i, id := range ids {
+		if len(id) > report.MaxLength {
+			report.MaxLength = len(id)
+		}
+		if i == 0 {
+			continue
+		}
+		dist := l.CountBetween(ids[i-1], id)
+		if report.TightestGap == -1 || dist.Cmp(report.MinDistance) < 0 {
+			report.TightestGap = i - 1
+			report.MinDistance = dist
+		}
+	}
+	// halving n free IDs leaves (n-1)/2 of them, so floor(log2(n+1)) inserts fit
+	report.InsertsBeforeGrowth = new(big.Int).Add(report.MinDistance, big.NewInt(1)).BitLen() - 1
+	return report
+}
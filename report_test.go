@@ -0,0 +1,47 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_ExhaustionReport(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("empty", func(t *testing.T) {
+		report := lid.ExhaustionReport(nil)
+		assert.Equal(t, -1, report.TightestGap)
+		assert.Equal(t, int64(0), report.MinDistance.Int64())
+		assert.Equal(t, 0, report.MaxLength)
+		report = lid.ExhaustionReport([]string{"abc"})
+		assert.Equal(t, -1, report.TightestGap)
+		assert.Equal(t, 3, report.MaxLength)
+	})
+	t.Run("tightest gap", func(t *testing.T) {
+		ids := []string{"001", "00b", "00d", "00z", "010001"}
+		report := lid.ExhaustionReport(ids)
+		assert.Equal(t, 1, report.TightestGap)
+		// 00c
+		assert.Equal(t, int64(1), report.MinDistance.Int64())
+		assert.Equal(t, 6, report.MaxLength)
+		assert.Equal(t, 1, report.InsertsBeforeGrowth)
+	})
+	t.Run("inserts estimate", func(t *testing.T) {
+		ids := lid.GenerateAfter("", 10)
+		report := lid.ExhaustionReport(ids)
+		// 9 valid ids between the neighbors
+		assert.Equal(t, int64(9), report.MinDistance.Int64())
+		require.Equal(t, 3, report.InsertsBeforeGrowth)
+		prev, before := ids[report.TightestGap], ids[report.TightestGap+1]
+		for i := 0; i < report.InsertsBeforeGrowth; i++ {
+			key, err := lid.SplitKey(prev, before)
+			require.NoError(t, err)
+			assert.Len(t, key, 3)
+			before = key
+		}
+		key, err := lid.SplitKey(prev, before)
+		require.NoError(t, err)
+		assert.Len(t, key, 6)
+	})
+}
@@ -0,0 +1,45 @@
+package lexid
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Rewiden maps the sorted ids of the Lexid to IDs of the target with a different blockSize and the same alphabet,
+// keeping the order. IDs with the length that is already a multiple of the target blockSize are kept as is,
+// other IDs are padded with lower chars and replaced by a target ID between the padded ID and the next padded ID.
+func (l Lexid) Rewiden(ids []string, target *Lexid) ([]string, error) {
+	if !bytes.Equal(l.chars, target.chars) {
+		return nil, fmt.Errorf("%w: target alphabet differs", ErrInvalidConfig)
+	}
+	padded := make([]string, len(ids))
+	for i, id := range ids {
+		if err := l.Validate(id); err != nil {
+			return nil, err
+		}
+		if i > 0 && !l.less(ids[i-1], id) {
+			return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrNotSorted, id, ids[i-1])
+		}
+		// padding with lower chars keeps the order: the padded ids have the same numeric value as fractions
+		padded[i] = id
+		for len(padded[i])%target.blockSize != 0 {
+			padded[i] += string(l.lower)
+		}
+	}
+	res := make([]string, len(ids))
+	for i, id := range padded {
+		switch {
+		case target.IsValid(id):
+			res[i] = id
+		case i+1 < len(padded):
+			next, err := target.NextBefore(id, padded[i+1])
+			if err != nil {
+				return nil, err
+			}
+			res[i] = next
+		default:
+			res[i] = target.Next(id)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package lexid
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Rewiden(t *testing.T) {
+	src := Must(CharsAlphanumericLower, 3, 10)
+	dst := Must(CharsAlphanumericLower, 4, 10)
+	checkSorted := func(t *testing.T, lid *Lexid, ids []string) {
+		for i, id := range ids {
+			require.True(t, lid.IsValid(id), id)
+			if i > 0 {
+				require.Less(t, ids[i-1], id)
+			}
+		}
+	}
+	t.Run("next and between", func(t *testing.T) {
+		ids := src.GenerateAfter("", 2000)
+		var inserted []string
+		for i := 0; i+1 < len(ids); i += 7 {
+			next, err := src.NextBefore(ids[i], ids[i+1])
+			require.NoError(t, err)
+			inserted = append(inserted, next)
+			prev := src.Prev(ids[i])
+			inserted = append(inserted, prev)
+		}
+		ids = append(ids, inserted...)
+		ids = append(ids, "001000001", "001001", "000zzz", "zzz", "zzzzzz002")
+		sort.Strings(ids)
+		ids = dedup(ids)
+		res, err := src.Rewiden(ids, dst)
+		require.NoError(t, err)
+		assert.Len(t, res, len(ids))
+		checkSorted(t, dst, res)
+		// the aligned ids are kept
+		for i, id := range ids {
+			if len(id)%4 == 0 {
+				assert.Equal(t, id, res[i])
+			}
+		}
+	})
+	t.Run("narrow", func(t *testing.T) {
+		ids := dst.GenerateAfter("", 1000)
+		res, err := dst.Rewiden(ids, src)
+		require.NoError(t, err)
+		checkSorted(t, src, res)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := src.Rewiden([]string{"001"}, Must(CharsAlphanumeric, 4, 1))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = src.Rewiden([]string{"002", "001"}, dst)
+		assert.ErrorIs(t, err, ErrNotSorted)
+		_, err = src.Rewiden([]string{"0010"}, dst)
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+}
+
+// dedup removes repeated items from the sorted slice
+func dedup(ids []string) []string {
+	res := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			res = append(res, id)
+		}
+	}
+	return res
+}
@@ -0,0 +1,99 @@
+package lexid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithShortGreater inverts the prefix ordering convention: an ID sorts after all longer IDs it's a prefix of.
+// Generated IDs are transformed: every char is replaced by its mirror in the alphabet (the i-th char by the (len-1-i)-th)
+// and the terminator byte following the max char of the alphabet is appended. The mirror reverses the order, so the methods
+// work on the regular IDs with swapped bounds, and FirstChild returns a descendant sorting before the parent.
+// Transformed IDs are compared with plain byte comparison. TightBetween and GreatestBelow don't support the ordering.
+// The max char of the alphabet must be less than 255.
+func WithShortGreater(enabled bool) Option {
+	return func(l *Lexid) error {
+		if enabled && l.upper == 255 {
+			return fmt.Errorf("%w: chars must not contain the byte 255 to use short greater ordering", ErrInvalidChars)
+		}
+		l.shortGreater = enabled
+		return nil
+	}
+}
+
+// terminator returns the byte appended to IDs in short greater ordering
+func (l Lexid) terminator() byte {
+	return l.upper + 1
+}
+
+// toShortGreater transforms the id from the regular ordering to the short greater ordering
+func (l Lexid) toShortGreater(id string) string {
+	return l.mirror(id) + string(l.terminator())
+}
+
+// fromShortGreater transforms the id from the short greater ordering to the regular ordering
+func (l Lexid) fromShortGreater(id string) string {
+	return l.mirror(strings.TrimSuffix(id, string(l.terminator())))
+}
+
+// mirror replaces every char by its mirror in the alphabet, it reverses the order of ids
+func (l Lexid) mirror(id string) string {
+	res := []byte(id)
+	for i, c := range res {
+		if idx := l.charIndex[c]; idx != -1 {
+			res[i] = l.chars[len(l.chars)-1-idx]
+		}
+	}
+	return string(res)
+}
+
+// regular returns the copy of the Lexid working with regular IDs without the namespace,
+// the namespace counts towards the min length
+func (l Lexid) regular() Lexid {
+	r := l.withoutNamespace()
+	r.shortGreater = false
+	if r.minLength -= len(l.namespace); r.minLength < 0 {
+		r.minLength = 0
+	}
+	return r
+}
+
+// toRegular returns the id without the namespace in the regular ordering
+func (l Lexid) toRegular(id string) string {
+	return l.fromShortGreater(strings.TrimPrefix(id, l.namespace))
+}
+
+// fromRegular returns the id in the short greater ordering with the namespace for the regular id
+func (l Lexid) fromRegular(id string) string {
+	return l.namespace + l.toShortGreater(id)
+}
+
+// fromRegularSlice transforms the ascending regular ids in place into ascending ids in the short greater ordering
+func (l Lexid) fromRegularSlice(ids []string) []string {
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	for i := range ids {
+		ids[i] = l.fromRegular(ids[i])
+	}
+	return ids
+}
+
+// shortGreaterBetween calls between for the swapped bounds in the regular ordering and transforms the result back.
+// Empty prev is the start of the list, see regularBounds.
+func (l Lexid) shortGreaterBetween(prev, before string, between func(r Lexid, prev, before string) (string, error)) (string, error) {
+	if !l.less(prev, before) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	rPrev, rBefore := l.regularTailBounds(prev, before)
+	id, err := between(l.regular(), rPrev, rBefore)
+	if err != nil {
+		return "", err
+	}
+	return l.fromRegular(id), nil
+}
+
+// regularTailBounds is regularBounds for the bounds with the namespace
+func (l Lexid) regularTailBounds(prev, before string) (rPrev, rBefore string) {
+	return l.regularBounds(strings.TrimPrefix(prev, l.namespace), strings.TrimPrefix(before, l.namespace))
+}
@@ -0,0 +1,209 @@
+package lexid
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithShortGreater(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1, WithShortGreater(true))
+	t.Run("transform", func(t *testing.T) {
+		assert.Equal(t, "zzy{", lid.toShortGreater("001"))
+		assert.Equal(t, "001", lid.fromShortGreater("zzy{"))
+		assert.Equal(t, "hzy{", lid.Middle())
+	})
+	t.Run("next", func(t *testing.T) {
+		var prev = lid.Middle()
+		var grew bool
+		for i := 0; i < 100000; i++ {
+			next, nextGrew := lid.NextInfo(prev)
+			require.Greater(t, next, prev)
+			if !nextGrew {
+				assert.Equal(t, prev, lid.Prev(next))
+			}
+			grew = grew || nextGrew
+			prev = next
+		}
+		assert.True(t, grew)
+	})
+	t.Run("prev", func(t *testing.T) {
+		var next = lid.Middle()
+		for i := 0; i < 100000; i++ {
+			prev := lid.Prev(next)
+			require.Less(t, prev, next)
+			next = prev
+		}
+	})
+	t.Run("inverted sort", func(t *testing.T) {
+		short := lid.Next("")
+		// the regular form of short extended by a block
+		long := lid.toShortGreater(lid.fromShortGreater(short) + "i01")
+		require.True(t, lid.IsValid(short))
+		require.True(t, lid.IsValid(long))
+		ids := []string{short, long}
+		sort.Strings(ids)
+		assert.Equal(t, []string{long, short}, ids)
+
+		// the regular forms sort the other way
+		regular := Must(CharsAlphanumericLower, 3, 1)
+		ids = []string{lid.fromShortGreater(long), lid.fromShortGreater(short)}
+		sort.Strings(ids)
+		assert.Equal(t, []string{lid.fromShortGreater(short), lid.fromShortGreater(long)}, ids)
+		assert.True(t, regular.IsValid(ids[0]))
+		assert.True(t, regular.IsValid(ids[1]))
+	})
+	t.Run("next before", func(t *testing.T) {
+		next, err := lid.NextBefore("hzy{", "i09{")
+		require.NoError(t, err)
+		assertShortGreaterBetween(t, lid, "hzy{", "i09{", next)
+
+		ids := []string{lid.Middle()}
+		for i := 0; i < 1000; i++ {
+			// insert at the front, into the middle and at the back
+			next, err = lid.NextBefore("", ids[0])
+			require.NoError(t, err)
+			assertShortGreaterBetween(t, lid, "", ids[0], next)
+			ids = append([]string{next}, ids...)
+			m := len(ids) / 2
+			next, err = lid.Between(ids[m-1], ids[m])
+			require.NoError(t, err)
+			assertShortGreaterBetween(t, lid, ids[m-1], ids[m], next)
+			ids = append(ids[:m], append([]string{next}, ids[m:]...)...)
+			next, err = lid.Between(ids[len(ids)-1], "")
+			require.NoError(t, err)
+			assertShortGreaterBetween(t, lid, ids[len(ids)-1], "", next)
+			ids = append(ids, next)
+		}
+		assert.True(t, sort.StringsAreSorted(ids))
+	})
+	t.Run("next before n", func(t *testing.T) {
+		for _, bounds := range [][2]string{{"hzy{", "i09{"}, {"", lid.Middle()}, {"hzyzzy{", "hzy{"}} {
+			ids, err := lid.NextBeforeN(bounds[0], bounds[1], 50)
+			require.NoError(t, err)
+			require.Len(t, ids, 50)
+			prev := bounds[0]
+			for _, id := range ids {
+				assertShortGreaterBetween(t, lid, prev, bounds[1], id)
+				prev = id
+			}
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ns := lid.WithNamespace("abc")
+		before := ns.Middle()
+		next, err := ns.NextBefore("", before)
+		require.NoError(t, err)
+		assertShortGreaterBetween(t, ns, "", before, next)
+		ids, err := ns.NextBeforeN(next, before, 10)
+		require.NoError(t, err)
+		for _, id := range ids {
+			assertShortGreaterBetween(t, ns, next, before, id)
+		}
+	})
+	t.Run("invalid chars", func(t *testing.T) {
+		_, err := New("a\xff", 3, 1, WithShortGreater(true))
+		assert.Error(t, err)
+		_, err = New("a\xff", 3, 1, WithShortGreater(false))
+		assert.NoError(t, err)
+	})
+}
+
+// assertShortGreaterBetween checks that the id is valid and sorts strictly between prev and before, empty bounds are open
+func assertShortGreaterBetween(t *testing.T, lid *Lexid, prev, before, id string) {
+	t.Helper()
+	assert.NoError(t, lid.Validate(id))
+	if prev != "" {
+		assert.Greater(t, id, prev)
+	}
+	if before != "" {
+		assert.Less(t, id, before)
+	}
+}
+
+func TestWithShortGreater_Helpers(t *testing.T) {
+	for _, lid := range []*Lexid{
+		Must(CharsAlphanumericLower, 3, 10, WithShortGreater(true)),
+		Must(CharsAlphanumericLower, 3, 10, WithShortGreater(true)).WithNamespace("abc"),
+	} {
+		a := lid.Next("")
+		b := lid.Next(a)
+		long := lid.fromRegular(lid.toRegular(a) + "i01")
+		pairs := [][2]string{{a, b}, {"", a}, {long, a}}
+		t.Run("count between", func(t *testing.T) {
+			assert.Equal(t, int64(9), lid.CountBetween(a, b).Int64())
+			assert.False(t, lid.Adjacent(a, b))
+			assert.True(t, lid.Adjacent(a, lid.NextOne(a)))
+			assert.Zero(t, lid.CountBetween(b, a).Sign())
+			// all ids of the length before a
+			assert.Equal(t, lid.PrependBudget(a)*10+9, int(lid.CountBetween("", a).Int64()))
+		})
+		t.Run("between", func(t *testing.T) {
+			for _, pair := range pairs {
+				for name, f := range map[string]func(prev, before string) (string, error){
+					"split":     lid.SplitKey,
+					"midpoint":  lid.MidpointBefore,
+					"excluding": func(prev, before string) (string, error) { return lid.BetweenExcluding(prev, before, nil) },
+					"percentile": func(prev, before string) (string, error) {
+						return lid.Percentile(prev, before, 0.2)
+					},
+				} {
+					id, err := f(pair[0], pair[1])
+					require.NoError(t, err, name)
+					assertShortGreaterBetween(t, lid, pair[0], pair[1], id)
+				}
+			}
+			first, err := lid.Percentile("", a, 0.1)
+			require.NoError(t, err)
+			last, err := lid.Percentile("", a, 0.9)
+			require.NoError(t, err)
+			assert.Less(t, first, last)
+			mid, err := lid.MidpointBefore(a, b)
+			require.NoError(t, err)
+			other, err := lid.BetweenExcluding(a, b, []string{mid})
+			require.NoError(t, err)
+			assert.NotEqual(t, mid, other)
+			assertShortGreaterBetween(t, lid, a, b, other)
+		})
+		t.Run("pre split", func(t *testing.T) {
+			anchors, err := lid.PreSplit(a, b, 3)
+			require.NoError(t, err)
+			require.Len(t, anchors, 3)
+			prev := a
+			for _, anchor := range anchors {
+				assertShortGreaterBetween(t, lid, prev, b, anchor)
+				prev = anchor
+			}
+		})
+		t.Run("spread", func(t *testing.T) {
+			ids := lid.Spread(100)
+			parallel, err := lid.SpreadParallel(context.Background(), 100, 4)
+			require.NoError(t, err)
+			assert.Equal(t, ids, parallel)
+			assert.Equal(t, ids, lid.RebalanceTargets(100))
+			assert.True(t, sort.StringsAreSorted(ids))
+			for _, id := range ids {
+				require.NoError(t, lid.Validate(id))
+			}
+		})
+		t.Run("seq", func(t *testing.T) {
+			k1, k2 := lid.KeyForSeq(1), lid.KeyForSeq(2)
+			assert.NoError(t, lid.Validate(k1))
+			assert.Less(t, k1, k2)
+			assert.Less(t, lid.KeyForSeq(0), k1)
+			g := lid.NewAtomicGenerator(0)
+			g1, g2 := g.Next(), g.Next()
+			assert.NoError(t, lid.Validate(g1))
+			assert.Less(t, g1, g2)
+		})
+		t.Run("first child", func(t *testing.T) {
+			child := lid.FirstChild(a)
+			assert.NoError(t, lid.Validate(child))
+			// descendants sort before the parent in the short greater ordering
+			assertShortGreaterBetween(t, lid, lid.Prev(a), a, child)
+		})
+	}
+}
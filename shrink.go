@@ -0,0 +1,81 @@
+package lexid
+
+import "strings"
+
+// Shrink removes trailing padding from the id: a trailing block of upper chars only, as appended by Prev,
+// together with the blocks of lower chars only that are left before it, for example "abc000zzz" becomes "abc".
+// prev is the ID sorting right before the id, or empty for the first ID; the id is shrunk only while the result
+// stays valid and greater than prev. The result is a prefix of the id, so it also stays less than the ID after the id
+// and applying Shrink to a sorted slice, passing the already shrunk predecessor, keeps the order.
+func (l Lexid) Shrink(prev, id string) string {
+	for {
+		shrunk, ok := l.shrinkBlock(prev, id)
+		if !ok {
+			return id
+		}
+		id = shrunk
+	}
+}
+
+// shrinkBlock removes the trailing upper block and as many following lower blocks as possible,
+// so the result is the shortest valid prefix greater than prev. It returns false if there is no such prefix.
+func (l Lexid) shrinkBlock(prev, id string) (string, bool) {
+	end := len(id) - l.blockSize
+	if len(id)%l.blockSize != 0 || end <= len(l.namespace) || !l.isUpperBlock(id[end:]) {
+		return id, false
+	}
+	// prefixes ending with lower blocks have the same value, the shortest one is tried first
+	start := end
+	for start-l.blockSize > len(l.namespace) && l.isLowerBlock(id[start-l.blockSize:start]) {
+		start -= l.blockSize
+	}
+	for n := start; n <= end; n += l.blockSize {
+		shrunk := id[:n]
+		if len(shrunk) >= l.minLength && l.IsValid(shrunk) && (prev == "" || l.less(prev, shrunk)) {
+			return shrunk, true
+		}
+	}
+	return id, false
+}
+
+// Compact splits the id into the core and the number of trailing blocks consisting of upper chars only,
+// so long IDs produced by repeated Prev can be stored without the redundant padding. Expand restores the id.
+func (l Lexid) Compact(id string) (core string, padBlocks int) {
+	if len(id)%l.blockSize != 0 {
+		return id, 0
+	}
+	end := len(id)
+	for end-l.blockSize >= len(l.namespace) && l.isUpperBlock(id[end-l.blockSize:end]) {
+		end -= l.blockSize
+		padBlocks++
+	}
+	return id[:end], padBlocks
+}
+
+// Expand appends padBlocks blocks of upper chars to the core, it's the inverse of Compact
+func (l Lexid) Expand(core string, padBlocks int) string {
+	if padBlocks <= 0 {
+		return core
+	}
+	return core + strings.Repeat(string(l.upper), padBlocks*l.blockSize)
+}
+
+// isUpperBlock reports whether the block consists of upper chars only
+func (l Lexid) isUpperBlock(block string) bool {
+	for i := 0; i < len(block); i++ {
+		if block[i] != l.upper {
+			return false
+		}
+	}
+	return len(block) > 0
+}
+
+// isLowerBlock reports whether the block consists of lower chars only
+func (l Lexid) isLowerBlock(block string) bool {
+	for i := 0; i < len(block); i++ {
+		if block[i] != l.lower {
+			return false
+		}
+	}
+	return len(block) > 0
+}
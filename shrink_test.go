@@ -0,0 +1,129 @@
+package lexid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Shrink(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("shrink", func(t *testing.T) {
+		assert.Equal(t, "abc", lid.Shrink("", "abczzz"))
+		assert.Equal(t, "abc", lid.Shrink("", "abczzzzzz"))
+		assert.Equal(t, "abczzz", lid.Shrink("abc", "abczzzzzz"))
+		assert.Equal(t, "abc", lid.Shrink("abb", "abc000zzz"))
+		assert.Equal(t, "abc000001", lid.Shrink("abc000000zzz", "abc000001zzz"))
+	})
+	t.Run("no shrink", func(t *testing.T) {
+		assert.Equal(t, "zzz", lid.Shrink("", "zzz"))
+		assert.Equal(t, "abczzy", lid.Shrink("", "abczzy"))
+		assert.Equal(t, "abc0zz", lid.Shrink("", "abc0zz"))
+		assert.Equal(t, "abczz", lid.Shrink("", "abczz"))
+		// the result must stay greater than prev
+		assert.Equal(t, "abczzz", lid.Shrink("abczzy", "abczzz"))
+		assert.Equal(t, "abc000zzz", lid.Shrink("abc", "abc000zzz"))
+		// the rest would end with the lower char
+		assert.Equal(t, "000zzz", lid.Shrink("", "000zzz"))
+		assert.Equal(t, "ab0000zzz", lid.Shrink("", "ab0000zzz"))
+		assert.Equal(t, "000zzz", lid.Shrink("", lid.Prev("001")))
+	})
+	t.Run("sorted slice", func(t *testing.T) {
+		ids := []string{"abczzx", "abczzy", "abczzz"}
+		var prev string
+		for i := range ids {
+			ids[i] = lid.Shrink(prev, ids[i])
+			prev = ids[i]
+		}
+		assert.Equal(t, []string{"abczzx", "abczzy", "abczzz"}, ids)
+	})
+	t.Run("padded slice", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 50)
+		var ids []string
+		var prev string
+		for i := 0; i < 500; i++ {
+			prev = lid.Next(prev)
+			if i%3 == 0 {
+				ids = append(ids, prev+"zzz")
+			} else {
+				ids = append(ids, prev)
+			}
+		}
+		assertShrinkOrder(t, lid, ids)
+		prev = ""
+		for i, id := range ids {
+			prev = lid.Shrink(prev, id)
+			assert.Len(t, prev, 3, i)
+		}
+	})
+	t.Run("prev run", func(t *testing.T) {
+		// a prepend-heavy session with every 7th ID left after deletes
+		lid := Must("0123", 2, 1)
+		run := []string{"1201"}
+		for i := 0; i < 3000; i++ {
+			run = append([]string{lid.Prev(run[0])}, run...)
+		}
+		assertShrinkOrder(t, lid, run)
+		var ids []string
+		for i := 0; i < len(run); i += 7 {
+			ids = append(ids, run[i])
+		}
+		assertShrinkOrder(t, lid, ids)
+	})
+}
+
+// assertShrinkOrder applies Shrink to the sorted ids and checks that the result is valid and sorted
+func assertShrinkOrder(t *testing.T, lid *Lexid, ids []string) {
+	var prev string
+	for i, id := range ids {
+		shrunk := lid.Shrink(prev, id)
+		assert.True(t, lid.IsValid(shrunk), shrunk)
+		assert.True(t, strings.HasPrefix(id, shrunk))
+		if i > 0 {
+			assert.Greater(t, shrunk, prev)
+		}
+		if i < len(ids)-1 {
+			assert.Less(t, shrunk, ids[i+1])
+		}
+		prev = shrunk
+	}
+}
+
+func TestLexid_Compact(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("compact", func(t *testing.T) {
+		core, n := lid.Compact("000000zzzzzz")
+		assert.Equal(t, "000000", core)
+		assert.Equal(t, 2, n)
+		core, n = lid.Compact("abc")
+		assert.Equal(t, "abc", core)
+		assert.Equal(t, 0, n)
+		core, n = lid.Compact("abczzy")
+		assert.Equal(t, "abczzy", core)
+		assert.Equal(t, 0, n)
+		core, n = lid.Compact("abczz")
+		assert.Equal(t, "abczz", core)
+		assert.Equal(t, 0, n)
+		core, n = lid.Compact("zzzzzz")
+		assert.Equal(t, "", core)
+		assert.Equal(t, 2, n)
+	})
+	t.Run("namespace", func(t *testing.T) {
+		nsLid := lid.WithNamespace("zzz")
+		core, n := nsLid.Compact("zzzzzz")
+		assert.Equal(t, "zzz", core)
+		assert.Equal(t, 1, n)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		next := "001"
+		for i := 0; i < 5000; i++ {
+			next = lid.Prev(next)
+			core, n := lid.Compact(next)
+			expanded := lid.Expand(core, n)
+			assert.Equal(t, next, expanded)
+			assert.True(t, lid.IsValid(expanded), expanded)
+		}
+		assert.Equal(t, "abc", lid.Expand("abc", 0))
+	})
+}
@@ -0,0 +1,63 @@
+package lexid
+
+import "sort"
+
+// radixSortMin is the min number of IDs to use the radix sort, smaller slices are sorted with sort.Strings
+const radixSortMin = 64
+
+// Sort sorts the ids in place in the lexicographical order, the result is the same as of sort.Strings.
+// IDs of the same length consisting of alphabet chars are decoded once and sorted with the radix sort,
+// other inputs fall back to sort.Strings.
+func (l Lexid) Sort(ids []string) {
+	if len(ids) < radixSortMin || l.ordered || !l.radixSort(ids) {
+		sort.Strings(ids)
+	}
+}
+
+// radixSort sorts ids with the LSD radix sort by char indexes, it returns false leaving ids untouched
+// if ids have different lengths or contain chars not in the alphabet
+func (l Lexid) radixSort(ids []string) bool {
+	length := len(ids[0])
+	digits := make([]byte, len(ids)*length)
+	for i, id := range ids {
+		if len(id) != length {
+			return false
+		}
+		for j := 0; j < length; j++ {
+			idx := l.charIndex[id[j]]
+			if idx == -1 {
+				return false
+			}
+			digits[i*length+j] = byte(idx)
+		}
+	}
+	perm := make([]int, len(ids))
+	for i := range perm {
+		perm[i] = i
+	}
+	buf := make([]int, len(ids))
+	counts := make([]int, len(l.chars)+1)
+	for pos := length - 1; pos >= 0; pos-- {
+		for i := range counts {
+			counts[i] = 0
+		}
+		for _, p := range perm {
+			counts[digits[p*length+pos]+1]++
+		}
+		for i := 1; i < len(counts); i++ {
+			counts[i] += counts[i-1]
+		}
+		for _, p := range perm {
+			d := digits[p*length+pos]
+			buf[counts[d]] = p
+			counts[d]++
+		}
+		perm, buf = buf, perm
+	}
+	sorted := make([]string, len(ids))
+	for i, p := range perm {
+		sorted[i] = ids[p]
+	}
+	copy(ids, sorted)
+	return true
+}
@@ -0,0 +1,74 @@
+package lexid
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomIDs returns n random valid ids of the given length
+func randomIDs(lid *Lexid, r *rand.Rand, n, length int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		id := make([]byte, length)
+		for j := range id {
+			id[j] = lid.chars[r.Intn(len(lid.chars))]
+		}
+		ids[i] = string(id)
+	}
+	return ids
+}
+
+func TestLexid_Sort(t *testing.T) {
+	lid := Must(CharsAlphanumeric, 4, 1)
+	r := rand.New(rand.NewSource(1))
+	check := func(t *testing.T, ids []string) {
+		expected := append([]string(nil), ids...)
+		sort.Strings(expected)
+		lid.Sort(ids)
+		assert.Equal(t, expected, ids)
+	}
+	t.Run("same length", func(t *testing.T) {
+		check(t, randomIDs(lid, r, 1000, 20))
+		check(t, randomIDs(lid, r, 10, 4))
+		ids := randomIDs(lid, r, 500, 3)
+		check(t, append(ids, ids...))
+	})
+	t.Run("different length", func(t *testing.T) {
+		check(t, append(randomIDs(lid, r, 500, 8), randomIDs(lid, r, 500, 4)...))
+	})
+	t.Run("not in alphabet", func(t *testing.T) {
+		check(t, append(randomIDs(lid, r, 500, 4), "a-bc"))
+	})
+	t.Run("small", func(t *testing.T) {
+		lid.Sort(nil)
+		check(t, []string{"b", "a"})
+	})
+	t.Run("ordered", func(t *testing.T) {
+		lid, err := NewOrdered("zyx0123", 2, 1)
+		assert.NoError(t, err)
+		check(t, randomIDs(lid, r, 1000, 6))
+	})
+}
+
+func BenchmarkLexid_Sort(b *testing.B) {
+	lid := Must(CharsAlphanumeric, 4, 1)
+	ids := randomIDs(lid, rand.New(rand.NewSource(1)), 10000, 20)
+	buf := make([]string, len(ids))
+	b.Run("Sort", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(buf, ids)
+			lid.Sort(buf)
+		}
+	})
+	b.Run("sort.Strings", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(buf, ids)
+			sort.Strings(buf)
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package lexid
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SplitKey returns the ID at the exact midpoint between prev and next, intended for splitting an ordered list in half.
+// Unlike NextBefore it doesn't use stepSize, so repeated splits of the same gap grow the key by one block
+// only when the gap is exhausted at the current length.
+func (l Lexid) SplitKey(prev, next string) (string, error) {
+	if l.shortGreater {
+		return l.shortGreaterBetween(prev, next, Lexid.SplitKey)
+	}
+	if !l.less(prev, next) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, next, prev)
+	}
+	length := l.alignedLength(prev, next)
+	for {
+		va, vb := l.toIntLength(prev, length), l.toIntLength(next, length)
+		if l.countBetweenInt(va, vb).Sign() > 0 {
+			return l.fromInt(l.midpointInt(va, vb), length), nil
+		}
+		length += l.blockSize
+	}
+}
+
+// Percentile returns the ID at the fraction p of the interval between a and b, so p=0.5 is the same as SplitKey.
+// The length grows by blocks until there is a valid ID between the bounds.
+func (l Lexid) Percentile(a, b string, p float64) (string, error) {
+	if !(p > 0 && p < 1) {
+		return "", fmt.Errorf("%w: percentile must be between 0 and 1, got %v", ErrInvalidConfig, p)
+	}
+	if l.shortGreater {
+		// the fraction of the reversed interval
+		return l.shortGreaterBetween(a, b, func(r Lexid, a, b string) (string, error) {
+			return r.Percentile(a, b, 1-p)
+		})
+	}
+	if !l.less(a, b) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, b, a)
+	}
+	frac := new(big.Rat).SetFloat64(p)
+	length := l.alignedLength(a, b)
+	for {
+		va, vb := l.toIntLength(a, length), l.toIntLength(b, length)
+		if l.countBetweenInt(va, vb).Sign() > 0 {
+			v := new(big.Int).Sub(vb, va)
+			v.Mul(v, frac.Num())
+			v.Quo(v, frac.Denom())
+			v.Add(v, va)
+			return l.fromInt(l.nearestValidInt(v, va, vb), length), nil
+		}
+		length += l.blockSize
+	}
+}
+
+// MidpointBefore returns the ID closest to the middle of prev and before among the shortest valid IDs between them.
+// Lengths are tried block by block starting at the min id length, so no shorter valid ID exists between the bounds.
+func (l Lexid) MidpointBefore(prev, before string) (string, error) {
+	if l.shortGreater {
+		return l.shortGreaterBetween(prev, before, Lexid.MidpointBefore)
+	}
+	if !l.less(prev, before) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	maxLength := l.alignedLength(prev, before) + l.blockSize
+	for length := l.minIDLength(); length <= maxLength; length += l.blockSize {
+		va, vb := l.boundsAtLength(prev, before, length)
+		if l.countBetweenInt(va, vb).Sign() > 0 {
+			return l.fromInt(l.midpointInt(va, vb), length), nil
+		}
+	}
+	return "", fmt.Errorf("%w: '%s' and '%s'", ErrNoMidpoint, prev, before)
+}
+
+// BetweenExcluding returns the shortest ID between prev and before like MidpointBefore, but never one of the excluded IDs,
+// for example tombstones of deleted items. The ID closest to the midpoint is chosen among the allowed ones of the length.
+// It returns ErrExhausted only if all IDs between the bounds are excluded up to the max length, see WithMaxLength.
+func (l Lexid) BetweenExcluding(prev, before string, excluded []string) (string, error) {
+	if l.shortGreater {
+		regularExcluded := make([]string, len(excluded))
+		for i, id := range excluded {
+			regularExcluded[i] = l.toRegular(id)
+		}
+		return l.shortGreaterBetween(prev, before, func(r Lexid, prev, before string) (string, error) {
+			return r.BetweenExcluding(prev, before, regularExcluded)
+		})
+	}
+	if !l.less(prev, before) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	skip := make(map[string]bool, len(excluded))
+	for _, id := range excluded {
+		skip[id] = true
+	}
+	for length := l.minIDLength(); l.maxLength == 0 || length <= l.maxLength; length += l.blockSize {
+		va, vb := l.boundsAtLength(prev, before, length)
+		if l.countBetweenInt(va, vb).Sign() == 0 {
+			continue
+		}
+		// look for the allowed value around the midpoint, there are at most len(excluded) excluded values on each side
+		mid := l.midpointInt(va, vb)
+		lo, hi := mid, new(big.Int).Set(mid)
+		for i := 0; i <= len(excluded); i++ {
+			if lo != nil {
+				if id := l.fromInt(lo, length); !skip[id] {
+					return id, nil
+				}
+			}
+			if hi != nil && i > 0 {
+				if id := l.fromInt(hi, length); !skip[id] {
+					return id, nil
+				}
+			}
+			lo, hi = l.validStep(lo, va, -1), l.validStep(hi, vb, 1)
+		}
+	}
+	return "", fmt.Errorf("%w: all ids between '%s' and '%s' are excluded", ErrExhausted, prev, before)
+}
+
+// validStep moves v to the next valid value in the direction dir and returns nil if it reaches the bound or v is nil
+func (l Lexid) validStep(v, bound *big.Int, dir int64) *big.Int {
+	if v == nil {
+		return nil
+	}
+	base := big.NewInt(int64(len(l.chars)))
+	next := new(big.Int).Add(v, big.NewInt(dir))
+	if !l.trailingMin && new(big.Int).Rem(next, base).Sign() == 0 {
+		next.Add(next, big.NewInt(dir))
+	}
+	if dir > 0 && next.Cmp(bound) >= 0 || dir < 0 && next.Cmp(bound) <= 0 {
+		return nil
+	}
+	return next
+}
+
+// boundsAtLength returns the exclusive bounds of the values of IDs of the length between prev and before
+func (l Lexid) boundsAtLength(prev, before string, length int) (va, vb *big.Int) {
+	// ids of the length are greater than prev if greater than its prefix of the length
+	p := prev
+	if len(p) > length {
+		p = p[:length]
+	}
+	// with WithAllowTrailingMin the padded prev is a valid id greater than prev, but it's excluded as well:
+	// nothing sorts between an id and the id followed by lower chars only, so it would leave no room for inserts
+	va = l.toIntLength(p, length)
+	// ids of the length are less than before if less than the padded before or equal to its prefix
+	if len(before) > length {
+		vb = l.toInt(before[:length])
+		vb.Add(vb, big.NewInt(1))
+	} else {
+		vb = l.toIntLength(before, length)
+	}
+	return va, vb
+}
+
+// TightBetween returns the shortest ID between prev and before like MidpointBefore, but only the tails after
+// the common block-aligned prefix are compared, so deep keys with long shared prefixes stay short and cheap to compute.
+// Empty prev means the start of the list. Generators created with WithShortGreater are not supported, use NextBefore.
+func (l Lexid) TightBetween(prev, before string) (string, error) {
+	if l.shortGreater {
+		return "", fmt.Errorf("%w: TightBetween doesn't support the short greater ordering", ErrInvalidConfig)
+	}
+	if !l.less(prev, before) {
+		return "", fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, before, prev)
+	}
+	prefix := l.CommonPrefix(prev, before)
+	// the prefix covers the namespace and counts towards the min length
+	tail := l.withoutNamespace()
+	if tail.minLength -= len(prefix); tail.minLength < 0 {
+		tail.minLength = 0
+	}
+	next, err := tail.MidpointBefore(prev[len(prefix):], before[len(prefix):])
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' and '%s'", ErrNoMidpoint, prev, before)
+	}
+	return prefix + next, nil
+}
+
+// CenterOf returns the key at the midpoint between the first and the last IDs of the sorted slice, see SplitKey.
+// For the empty slice it returns Middle and for a single ID the following ID as Between(ids[0], "") does.
+// Generators created by NewFractionalCompat use Between for the midpoint.
+func (l Lexid) CenterOf(ids []string) (string, error) {
+	switch {
+	case len(ids) == 0:
+		return l.Middle(), nil
+	case len(ids) == 1:
+		return l.Between(ids[0], "")
+	case l.fractional:
+		return l.Between(ids[0], ids[len(ids)-1])
+	}
+	return l.SplitKey(ids[0], ids[len(ids)-1])
+}
+
+// FillGap inserts count evenly spaced keys between prev and next like NextBeforeN
+// and also returns the length of the longest key, so the caller can decide whether to rebalance.
+// It returns an error if the keys don't fit in the gap within the max length.
+func (l Lexid) FillGap(prev, next string, count int) (keys []string, maxLen int, err error) {
+	if keys, err = l.NextBeforeN(prev, next, count); err != nil {
+		return nil, 0, err
+	}
+	for _, key := range keys {
+		if len(key) > maxLen {
+			maxLen = len(key)
+		}
+	}
+	return keys, maxLen, nil
+}
+
+// PreSplit returns factor evenly spaced anchor keys between prev and next for a hot gap that gets many inserts.
+// Unlike FillGap the anchors use the shortest length where each of the factor+1 parts of the gap has room
+// for stepSize IDs, so later inserts into the parts step without growing. Store the anchors as placeholders
+// and insert new items between the neighboring anchors, for example NextBefore(anchors[i], anchors[i+1]),
+// choosing the part by the expected position. It returns an error if the anchors exceed the max length.
+func (l Lexid) PreSplit(prev, next string, factor int) ([]string, error) {
+	if factor < 1 {
+		return nil, fmt.Errorf("%w: factor must be positive, got %d", ErrInvalidConfig, factor)
+	}
+	if !l.less(prev, next) {
+		return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrBeforeNotGreater, next, prev)
+	}
+	if l.shortGreater {
+		rPrev, rNext := l.regularTailBounds(prev, next)
+		anchors, err := l.regular().PreSplit(rPrev, rNext, factor)
+		if err != nil {
+			return nil, err
+		}
+		anchors = l.fromRegularSlice(anchors)
+		if err = l.checkMaxLength(anchors[0]); err != nil {
+			return nil, err
+		}
+		return anchors, nil
+	}
+	parts := big.NewInt(int64(factor + 1))
+	// the anchors themselves and stepSize IDs in every part
+	room := new(big.Int).Mul(parts, big.NewInt(int64(l.stepSize)))
+	room.Add(room, big.NewInt(int64(factor)))
+	length := l.alignedLength(prev, next)
+	va, vb := l.toIntLength(prev, length), l.toIntLength(next, length)
+	for l.countBetweenInt(va, vb).Cmp(room) < 0 {
+		length += l.blockSize
+		va, vb = l.toIntLength(prev, length), l.toIntLength(next, length)
+	}
+	dist := new(big.Int).Sub(vb, va)
+	anchors := make([]string, factor)
+	v := new(big.Int)
+	for i := range anchors {
+		v.SetInt64(int64(i + 1))
+		v.Mul(v, dist)
+		v.Quo(v, parts)
+		v.Add(v, va)
+		anchors[i] = l.fromInt(l.nearestValidInt(v, va, vb), length)
+	}
+	if err := l.checkMaxLength(anchors[factor-1]); err != nil {
+		return nil, err
+	}
+	return anchors, nil
+}
@@ -0,0 +1,359 @@
+package lexid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_SplitKey(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	t.Run("midpoint", func(t *testing.T) {
+		key, err := lid.SplitKey("001", "003")
+		require.NoError(t, err)
+		assert.Equal(t, "002", key)
+		key, err = lid.SplitKey("000", "00z")
+		require.NoError(t, err)
+		assert.Equal(t, "00h", key)
+		key, err = lid.SplitKey("001", "002")
+		require.NoError(t, err)
+		assert.Equal(t, "001i01", key)
+	})
+	t.Run("same gap 20 times", func(t *testing.T) {
+		prev, next := "001", "002"
+		for i := 0; i < 20; i++ {
+			key, err := lid.SplitKey(prev, next)
+			require.NoError(t, err)
+			assert.Greater(t, key, prev)
+			assert.Less(t, key, next)
+			assert.False(t, strings.HasSuffix(key, "0"), key)
+			next = key
+		}
+		// each block contains 36^3 values, so 20 halvings take only two more blocks
+		assert.Len(t, next, 9)
+	})
+	t.Run("incorrect next", func(t *testing.T) {
+		_, err := lid.SplitKey("002", "001")
+		assert.Error(t, err)
+	})
+}
+
+func TestLexid_Percentile(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	t.Run("midpoint", func(t *testing.T) {
+		for _, tc := range [][2]string{{"001", "003"}, {"000", "00z"}, {"001", "002"}, {"abc", "abc001"}, {"", "zzz"}} {
+			mid, err := lid.SplitKey(tc[0], tc[1])
+			require.NoError(t, err)
+			p, err := lid.Percentile(tc[0], tc[1], 0.5)
+			require.NoError(t, err)
+			assert.Equal(t, mid, p, tc)
+		}
+	})
+	t.Run("fractions", func(t *testing.T) {
+		var prev string
+		for _, p := range []float64{0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99} {
+			id, err := lid.Percentile("100", "200", p)
+			require.NoError(t, err)
+			assert.Greater(t, id, "100")
+			assert.Less(t, id, "200")
+			assert.Greater(t, id, prev)
+			prev = id
+		}
+		id, err := lid.Percentile("000", "00a", 0.35)
+		require.NoError(t, err)
+		assert.Equal(t, "003", id)
+	})
+	t.Run("close bounds", func(t *testing.T) {
+		for _, p := range []float64{0.000001, 0.5, 0.999999} {
+			id, err := lid.Percentile("001", "002", p)
+			require.NoError(t, err)
+			assert.Greater(t, id, "001")
+			assert.Less(t, id, "002")
+			assert.False(t, strings.HasSuffix(id, "0"), id)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, p := range []float64{0, 1, -0.5, 2} {
+			_, err := lid.Percentile("001", "002", p)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		}
+		_, err := lid.Percentile("002", "001", 0.5)
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+}
+
+func TestLexid_MidpointBefore(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	t.Run("between min padding", func(t *testing.T) {
+		mid, err := lid.MidpointBefore("zzz", "zzz001")
+		require.NoError(t, err)
+		assert.Greater(t, mid, "zzz")
+		assert.Less(t, mid, "zzz001")
+		assert.Len(t, mid, 9)
+	})
+	t.Run("between padding", func(t *testing.T) {
+		mid, err := lid.MidpointBefore("zzz", "zzzz01")
+		require.NoError(t, err)
+		assert.Greater(t, mid, "zzz")
+		assert.Less(t, mid, "zzzz01")
+		assert.Len(t, mid, 6)
+	})
+	t.Run("shorter than bounds", func(t *testing.T) {
+		mid, err := lid.MidpointBefore("001", "005zzz")
+		require.NoError(t, err)
+		assert.Equal(t, "003", mid)
+		next, err := lid.NextBefore("", "005zzz")
+		require.NoError(t, err)
+		assert.Len(t, next, 3)
+	})
+	t.Run("minimal", func(t *testing.T) {
+		small := Must("0123", 2, 1)
+		// all valid ids up to 4 chars
+		var all []string
+		var gen func(prefix string)
+		gen = func(prefix string) {
+			for _, c := range "0123" {
+				id := prefix + string(c)
+				if len(id)%2 == 0 && c != '0' {
+					all = append(all, id)
+				}
+				if len(id) < 4 {
+					gen(id)
+				}
+			}
+		}
+		gen("")
+		for _, prev := range append([]string{""}, all...) {
+			for _, before := range []string{"01", "0001", "000001", "1101", "11", "1201", "33", "3333"} {
+				if prev >= before {
+					continue
+				}
+				mid, err := small.MidpointBefore(prev, before)
+				require.NoError(t, err)
+				assert.Greater(t, mid, prev)
+				assert.Less(t, mid, before)
+				assert.NoError(t, small.Validate(mid))
+				for _, id := range all {
+					if len(id) < len(mid) && id > prev && id < before {
+						t.Errorf("'%s' is shorter than '%s' between '%s' and '%s'", id, mid, prev, before)
+					}
+				}
+			}
+		}
+	})
+	t.Run("incorrect before", func(t *testing.T) {
+		_, err := lid.MidpointBefore("002", "001")
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+}
+
+func TestLexid_BetweenExcluding(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 100)
+	t.Run("no exclusions", func(t *testing.T) {
+		mid, err := lid.BetweenExcluding("001", "005zzz", nil)
+		require.NoError(t, err)
+		expected, err := lid.MidpointBefore("001", "005zzz")
+		require.NoError(t, err)
+		assert.Equal(t, expected, mid)
+	})
+	t.Run("closest to the midpoint", func(t *testing.T) {
+		mid, err := lid.BetweenExcluding("001", "005", []string{"003"})
+		require.NoError(t, err)
+		assert.Equal(t, "002", mid)
+		mid, err = lid.BetweenExcluding("001", "005", []string{"003", "002"})
+		require.NoError(t, err)
+		assert.Equal(t, "004", mid)
+	})
+	t.Run("grows when the length is excluded", func(t *testing.T) {
+		mid, err := lid.BetweenExcluding("001", "005", []string{"002", "003", "004", "00z"})
+		require.NoError(t, err)
+		assert.Len(t, mid, 6)
+		assert.Greater(t, mid, "001")
+		assert.Less(t, mid, "005")
+	})
+	t.Run("skips the trailing lower char", func(t *testing.T) {
+		mid, err := lid.BetweenExcluding("00z", "012", []string{"011"})
+		require.NoError(t, err)
+		assert.Len(t, mid, 6)
+		assert.NoError(t, lid.Validate(mid))
+	})
+	t.Run("random", func(t *testing.T) {
+		small := Must("0123", 1, 1)
+		var excluded []string
+		for i := 0; i < 30; i++ {
+			mid, err := small.BetweenExcluding("1", "2", excluded)
+			require.NoError(t, err)
+			assert.NotContains(t, excluded, mid)
+			assert.Greater(t, mid, "1")
+			assert.Less(t, mid, "2")
+			assert.NoError(t, small.Validate(mid))
+			for _, e := range excluded {
+				assert.LessOrEqual(t, len(e), len(mid))
+			}
+			excluded = append(excluded, mid)
+		}
+	})
+	t.Run("exhausted", func(t *testing.T) {
+		short, err := New("0123456789abcdefghijklmnopqrstuvwxyz", 3, 100, WithMaxLength(3))
+		require.NoError(t, err)
+		_, err = short.BetweenExcluding("001", "004", []string{"002", "003"})
+		assert.ErrorIs(t, err, ErrExhausted)
+		_, err = short.BetweenExcluding("001", "004", []string{"002"})
+		assert.NoError(t, err)
+	})
+	t.Run("incorrect before", func(t *testing.T) {
+		_, err := lid.BetweenExcluding("002", "001", nil)
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+	})
+}
+
+func TestLexid_TightBetween(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	t.Run("deep keys", func(t *testing.T) {
+		prefix := strings.Repeat("abc", 10)
+		prev, before := prefix+"k01zz1", prefix+"k02001"
+		mid, err := lid.TightBetween(prev, before)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(mid, prefix+"k0"), mid)
+		assert.Greater(t, mid, prev)
+		assert.Less(t, mid, before)
+		assert.NoError(t, lid.Validate(mid))
+		full, err := lid.MidpointBefore(prev, before)
+		require.NoError(t, err)
+		assert.Equal(t, full, mid)
+		next, err := lid.NextBefore(prev, before)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(mid), len(next))
+	})
+	t.Run("preserves order", func(t *testing.T) {
+		small := Must("0123", 2, 1)
+		ids := []string{"", "01", "0101", "010101", "0102", "010201", "0103", "11", "1101", "110201", "1103", "33", "3301", "333301"}
+		for i, prev := range ids {
+			for _, before := range ids[i+1:] {
+				mid, err := small.TightBetween(prev, before)
+				require.NoError(t, err)
+				assert.Greater(t, mid, prev)
+				assert.Less(t, mid, before)
+				assert.NoError(t, small.Validate(mid))
+				short, err := small.MidpointBefore(prev, before)
+				require.NoError(t, err)
+				assert.Len(t, mid, len(short), "'%s' and '%s'", prev, before)
+			}
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ns := lid.WithNamespace("ns1")
+		mid, err := ns.TightBetween("ns1abc001", "ns1abc003")
+		require.NoError(t, err)
+		assert.Equal(t, "ns1abc002", mid)
+		assert.NoError(t, ns.Validate(mid))
+	})
+	t.Run("min length", func(t *testing.T) {
+		long, err := New(CharsAlphanumericLower, 3, 100, WithMinLength(6))
+		require.NoError(t, err)
+		mid, err := long.TightBetween("abc001", "abc005")
+		require.NoError(t, err)
+		assert.Equal(t, "abc003", mid)
+		mid, err = long.TightBetween("", "abc005")
+		require.NoError(t, err)
+		assert.Len(t, mid, 6)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := lid.TightBetween("abc002", "abc001")
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+		sg, err := New(CharsAlphanumericLower, 3, 100, WithShortGreater(true))
+		require.NoError(t, err)
+		_, err = sg.TightBetween("", "abc")
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestLexid_CenterOf(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	center, err := lid.CenterOf(nil)
+	require.NoError(t, err)
+	assert.Equal(t, lid.Middle(), center)
+	center, err = lid.CenterOf([]string{"abc"})
+	require.NoError(t, err)
+	assert.Equal(t, lid.Next("abc"), center)
+	center, err = lid.CenterOf([]string{"001", "005", "00b", "00z"})
+	require.NoError(t, err)
+	assert.Equal(t, "00i", center)
+	_, err = lid.CenterOf([]string{"00z", "001"})
+	assert.ErrorIs(t, err, ErrBeforeNotGreater)
+
+	fc := NewFractionalCompat()
+	ids := []string{"a0", "a1", "a2"}
+	center, err = fc.CenterOf(ids)
+	require.NoError(t, err)
+	assert.Greater(t, center, ids[0])
+	assert.Less(t, center, ids[2])
+}
+
+func TestLexid_FillGap(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10, WithMaxLength(6))
+	keys, maxLen, err := lid.FillGap("001", "00b", 4)
+	require.NoError(t, err)
+	assert.Len(t, keys, 4)
+	assert.Equal(t, 3, maxLen)
+	keys, maxLen, err = lid.FillGap("001", "002", 100)
+	require.NoError(t, err)
+	assert.Len(t, keys, 100)
+	assert.Equal(t, 6, maxLen)
+	prev := "001"
+	for _, key := range keys {
+		assert.Greater(t, key, prev)
+		prev = key
+	}
+	assert.Less(t, prev, "002")
+	keys, maxLen, err = lid.FillGap("001", "002", 0)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Equal(t, 0, maxLen)
+	_, _, err = lid.FillGap("001", "002", 50000)
+	assert.ErrorIs(t, err, ErrMaxLength)
+	_, _, err = lid.FillGap("002", "001", 1)
+	assert.ErrorIs(t, err, ErrBeforeNotGreater)
+}
+
+func TestLexid_PreSplit(t *testing.T) {
+	lid := Must("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10)
+	t.Run("wide gap", func(t *testing.T) {
+		anchors, err := lid.PreSplit("001", "zzz", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"901", "i01", "qzz"}, anchors)
+	})
+	t.Run("anchors have room", func(t *testing.T) {
+		for _, bounds := range [][2]string{{"001", "002"}, {"00a", "00k"}, {"abc001", "abc003"}, {"", "001"}} {
+			anchors, err := lid.PreSplit(bounds[0], bounds[1], 4)
+			require.NoError(t, err)
+			require.Len(t, anchors, 4)
+			all := append(append([]string{bounds[0]}, anchors...), bounds[1])
+			for i := 1; i < len(all); i++ {
+				assert.Less(t, all[i-1], all[i])
+				if i < len(all)-1 {
+					assert.NoError(t, lid.Validate(all[i]))
+				}
+				// every part of the gap takes stepSize IDs without growing
+				assert.GreaterOrEqual(t, lid.CountBetween(all[i-1], all[i]).Int64(), int64(10), all)
+			}
+			for i := 1; i < len(anchors); i++ {
+				next, err := lid.NextBefore(anchors[i-1], anchors[i])
+				require.NoError(t, err)
+				assert.Len(t, next, len(anchors[i]))
+			}
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := lid.PreSplit("001", "002", 0)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		_, err = lid.PreSplit("002", "001", 2)
+		assert.ErrorIs(t, err, ErrBeforeNotGreater)
+		short, err := New("0123456789abcdefghijklmnopqrstuvwxyz", 3, 10, WithMaxLength(3))
+		require.NoError(t, err)
+		_, err = short.PreSplit("001", "002", 2)
+		assert.ErrorIs(t, err, ErrMaxLength)
+	})
+}
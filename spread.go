@@ -0,0 +1,194 @@
+package lexid
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// ctxCheckInterval is the number of generated IDs between context cancellation checks
+const ctxCheckInterval = 1024
+
+// Spread generates n evenly spaced IDs of the minimal equal length that fits n IDs.
+// Generators created by NewFractionalCompat return the keys of generateNKeysBetween(null, null, n) of fractional-indexing.
+func (l Lexid) Spread(n int) []string {
+	ids, _ := l.SpreadCtx(context.Background(), n)
+	return ids
+}
+
+// SpreadCtx is like Spread, but returns the context error if ctx is done before all IDs are generated
+func (l Lexid) SpreadCtx(ctx context.Context, n int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	if l.fractional {
+		return l.fractionalSpread(ctx, n)
+	}
+	if l.shortGreater {
+		ids, err := l.regular().SpreadCtx(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		return l.fromRegularSlice(ids), nil
+	}
+	blocks, count := l.spreadBlocks(n)
+	ids := make([]string, n)
+	if err := l.spreadRange(ctx, ids, 0, n, blocks, count); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SpreadParallel is like SpreadCtx, but splits the IDs between the workers goroutines, the result doesn't depend on workers.
+// Every ID is computed from its index, so the work is split into contiguous ranges of about n/workers IDs.
+func (l Lexid) SpreadParallel(ctx context.Context, n, workers int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	if l.fractional {
+		// every key depends on the previous one
+		return l.fractionalSpread(ctx, n)
+	}
+	if l.shortGreater {
+		ids, err := l.regular().SpreadParallel(ctx, n, workers)
+		if err != nil {
+			return nil, err
+		}
+		return l.fromRegularSlice(ids), nil
+	}
+	blocks, count := l.spreadBlocks(n)
+	ids := make([]string, n)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			from, to := n*w/workers, n*(w+1)/workers
+			errs[w] = l.spreadRange(ctx, ids[from:to], from, n, blocks, count)
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// spreadRange fills ids with the IDs of Spread(n) starting at the index from
+func (l Lexid) spreadRange(ctx context.Context, ids []string, from, n, blocks int, count *big.Int) error {
+	// place the i-th id at the center of the i-th of n equal parts of the valid ids space
+	denominator := big.NewInt(int64(2 * n))
+	idx := new(big.Int)
+	for i := range ids {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		idx.SetInt64(int64(2*(from+i) + 1))
+		idx.Mul(idx, count)
+		idx.Quo(idx, denominator)
+		ids[i] = l.namespace + l.fromValidIndex(idx, blocks)
+	}
+	return nil
+}
+
+// spreadBlocks returns the minimal number of blocks fitting n valid IDs and the number of valid IDs of the length
+func (l Lexid) spreadBlocks(n int) (int, *big.Int) {
+	blocks := l.minIDLength() / l.blockSize
+	count := l.validCount(blocks)
+	total := big.NewInt(int64(n))
+	for count.Cmp(total) < 0 {
+		blocks++
+		count = l.validCount(blocks)
+	}
+	return blocks, count
+}
+
+// GenerateAfter returns count successive IDs after start, every ID is Next of the previous one
+func (l Lexid) GenerateAfter(start string, count int) []string {
+	if count <= 0 {
+		return nil
+	}
+	ids := make([]string, count)
+	prev := start
+	for i := range ids {
+		prev = l.Next(prev)
+		ids[i] = prev
+	}
+	return ids
+}
+
+// Rebalance returns the new minimal equal length IDs for the given sorted slice of IDs.
+// The result has the same length and order as the input, the i-th ID of the result replaces the i-th ID of the input.
+func (l Lexid) Rebalance(ids []string) ([]string, error) {
+	return l.RebalanceCtx(context.Background(), ids)
+}
+
+// RebalanceCtx is like Rebalance, but returns the context error if ctx is done before the rebalance is finished
+func (l Lexid) RebalanceCtx(ctx context.Context, ids []string) ([]string, error) {
+	for i := 1; i < len(ids); i++ {
+		if !l.less(ids[i-1], ids[i]) {
+			return nil, fmt.Errorf("%w: '%s' less or equal '%s'", ErrNotSorted, ids[i], ids[i-1])
+		}
+	}
+	return l.SpreadCtx(ctx, len(ids))
+}
+
+// RebalanceTargets returns the IDs that Rebalance assigns to a sorted slice of count IDs, so the caller can preview
+// the rebalance and rewrite only the changed keys. The targets are Spread(count): evenly spaced, of the minimal equal length,
+// and the same for the same count and configuration.
+func (l Lexid) RebalanceTargets(count int) []string {
+	return l.Spread(count)
+}
+
+// RebalanceBenefit returns the max length of the sorted ids and the max length after Rebalance without generating new IDs,
+// so the caller can rebalance only when the difference pays off. The order of the ids is not checked.
+func (l Lexid) RebalanceBenefit(ids []string) (currentMax, rebalancedMax int) {
+	for _, id := range ids {
+		if len(id) > currentMax {
+			currentMax = len(id)
+		}
+	}
+	if len(ids) == 0 {
+		return currentMax, 0
+	}
+	blocks, _ := l.spreadBlocks(len(ids))
+	return currentMax, len(l.namespace) + blocks*l.blockSize
+}
+
+// LongestRun returns the start index and the length of the longest run of ids where every ID is Next of the previous one.
+// Dense runs have no room for inserts at the current length step, sparse parts are better for future inserts.
+// It returns the first run if there are several of the same length and 0, 0 for the empty slice.
+func (l Lexid) LongestRun(ids []string) (start int, length int) {
+	if len(ids) == 0 {
+		return 0, 0
+	}
+	length = 1
+	runStart := 0
+	for i := 1; i < len(ids); i++ {
+		if l.Next(ids[i-1]) != ids[i] {
+			runStart = i
+		}
+		if i-runStart+1 > length {
+			start, length = runStart, i-runStart+1
+		}
+	}
+	return start, length
+}
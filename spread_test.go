@@ -0,0 +1,177 @@
+package lexid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_Spread(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Empty(t, lid.Spread(0))
+	})
+	t.Run("single", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 1)
+		assert.Equal(t, []string{"i01"}, lid.Spread(1))
+	})
+	t.Run("minimal length", func(t *testing.T) {
+		lid := Must("0123", 2, 1)
+		// 4*3 valid ids with one block
+		ids := lid.Spread(12)
+		assert.Equal(t, []string{"01", "02", "03", "11", "12", "13", "21", "22", "23", "31", "32", "33"}, ids)
+		ids = lid.Spread(13)
+		for _, id := range ids {
+			assert.Len(t, id, 4)
+		}
+	})
+	t.Run("sorted", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 2, 1)
+		ids := lid.Spread(5000)
+		require.Len(t, ids, 5000)
+		for i, id := range ids {
+			assert.Len(t, id, 4)
+			assert.NotEqual(t, byte('0'), id[len(id)-1])
+			if i > 0 {
+				assert.Greater(t, id, ids[i-1])
+			}
+		}
+	})
+}
+
+func TestLexid_SpreadCtx(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("success", func(t *testing.T) {
+		ids, err := lid.SpreadCtx(context.Background(), 100)
+		require.NoError(t, err)
+		assert.Equal(t, lid.Spread(100), ids)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := lid.SpreadCtx(ctx, 100000)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = lid.RebalanceCtx(ctx, []string{"001", "002"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLexid_SpreadParallel(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("success", func(t *testing.T) {
+		for _, n := range []int{0, 1, 7, 5000} {
+			expected := lid.Spread(n)
+			for _, workers := range []int{0, 1, 3, 8, 10000} {
+				ids, err := lid.SpreadParallel(context.Background(), n, workers)
+				require.NoError(t, err)
+				assert.Equal(t, expected, ids, "n=%d workers=%d", n, workers)
+			}
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := lid.SpreadParallel(ctx, 100000, 4)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLexid_GenerateAfter(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	assert.Empty(t, lid.GenerateAfter("", 0))
+	ids := lid.GenerateAfter("zz0", 1000)
+	require.Len(t, ids, 1000)
+	prev := "zz0"
+	for _, id := range ids {
+		assert.Equal(t, lid.Next(prev), id)
+		assert.Greater(t, id, prev)
+		prev = id
+	}
+}
+
+func TestLexid_Rebalance(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	t.Run("success", func(t *testing.T) {
+		ids := []string{"001", "001i01", "001i01i01", "002", "00a"}
+		res, err := lid.Rebalance(ids)
+		require.NoError(t, err)
+		require.Len(t, res, len(ids))
+		assert.Equal(t, lid.Spread(len(ids)), res)
+		for i := 1; i < len(res); i++ {
+			assert.Greater(t, res[i], res[i-1])
+		}
+	})
+	t.Run("not sorted", func(t *testing.T) {
+		_, err := lid.Rebalance([]string{"002", "001"})
+		assert.Error(t, err)
+		_, err = lid.Rebalance([]string{"001", "001"})
+		assert.Error(t, err)
+	})
+}
+
+func TestLexid_RebalanceTargets(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	ids := []string{"001", "001i01", "001i01i01", "002", "00a"}
+	res, err := lid.Rebalance(ids)
+	require.NoError(t, err)
+	targets := lid.RebalanceTargets(len(ids))
+	assert.Equal(t, res, targets)
+	assert.Equal(t, targets, lid.RebalanceTargets(len(ids)))
+	for _, id := range targets {
+		assert.Len(t, id, 3)
+	}
+	assert.Len(t, lid.RebalanceTargets(36 * 36 * 36)[0], 6)
+	assert.Nil(t, lid.RebalanceTargets(0))
+}
+
+func TestLexid_RebalanceBenefit(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 100)
+	current, rebalanced := lid.RebalanceBenefit(nil)
+	assert.Equal(t, 0, current)
+	assert.Equal(t, 0, rebalanced)
+
+	ids := []string{"001", "001i01", "001i01i01", "002", "00a"}
+	current, rebalanced = lid.RebalanceBenefit(ids)
+	assert.Equal(t, 9, current)
+	assert.Equal(t, 3, rebalanced)
+
+	for _, n := range []int{1, 36 * 35, 36*36*35 + 1} {
+		ids := lid.Spread(n)
+		res, err := lid.Rebalance(ids)
+		require.NoError(t, err)
+		_, rebalanced := lid.RebalanceBenefit(ids)
+		assert.Len(t, res[len(res)-1], rebalanced, n)
+	}
+	current, rebalanced = lid.WithNamespace("ns1").RebalanceBenefit([]string{"ns1001", "ns1001i01"})
+	assert.Equal(t, 9, current)
+	assert.Equal(t, 6, rebalanced)
+}
+
+func TestLexid_LongestRun(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	start, length := lid.LongestRun(nil)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 0, length)
+	start, length = lid.LongestRun([]string{"abc"})
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 1, length)
+
+	run := lid.GenerateAfter("", 5)
+	start, length = lid.LongestRun(run)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 5, length)
+
+	short := lid.GenerateAfter("a00", 2)
+	long := lid.GenerateAfter("b00", 4)
+	ids := append(append(append([]string{}, short...), long...), "zzz")
+	start, length = lid.LongestRun(ids)
+	assert.Equal(t, 2, start)
+	assert.Equal(t, 4, length)
+
+	// NextOne steps are not consecutive for stepSize 10
+	start, length = lid.LongestRun([]string{"001", "002", "003"})
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 1, length)
+}
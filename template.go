@@ -0,0 +1,31 @@
+package lexid
+
+import (
+	"sync"
+	"text/template"
+)
+
+// FuncMap returns template functions bound to a new cursor:
+// "next" advances the cursor with Next and returns the new ID,
+// "middle" moves the cursor to Middle and returns it.
+// The cursor is safe for concurrent template executions, but IDs are shared between them.
+func (l *Lexid) FuncMap() template.FuncMap {
+	var (
+		mu     sync.Mutex
+		cursor string
+	)
+	return template.FuncMap{
+		"next": func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			cursor = l.Next(cursor)
+			return cursor
+		},
+		"middle": func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			cursor = l.Middle()
+			return cursor
+		},
+	}
+}
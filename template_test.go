@@ -0,0 +1,45 @@
+package lexid
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_FuncMap(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("template", func(t *testing.T) {
+		tmpl, err := template.New("").Funcs(lid.FuncMap()).Parse("{{next}} {{next}} {{middle}} {{next}}")
+		require.NoError(t, err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, tmpl.Execute(buf, nil))
+		assert.Equal(t, "002 003 i01 i02", buf.String())
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		tmpl, err := template.New("").Funcs(lid.FuncMap()).Parse("{{next}}")
+		require.NoError(t, err)
+		var (
+			wg  sync.WaitGroup
+			mu  sync.Mutex
+			ids = map[string]bool{}
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				buf := bytes.NewBuffer(nil)
+				assert.NoError(t, tmpl.Execute(buf, nil))
+				mu.Lock()
+				ids[strings.TrimSpace(buf.String())] = true
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, ids, 100)
+	})
+}
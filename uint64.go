@@ -0,0 +1,83 @@
+package lexid
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// ToUint64 returns the numeric value of a single block id. Shorter ids are padded with lower chars.
+// It returns false if the id is longer than blockSize, contains chars not in the alphabet or the value doesn't fit in uint64.
+// For ids that fit, the order of values matches the order of ids.
+func (l Lexid) ToUint64(id string) (uint64, bool) {
+	if len(id) > l.blockSize {
+		return 0, false
+	}
+	base := uint64(len(l.chars))
+	var v uint64
+	for i := 0; i < l.blockSize; i++ {
+		var digit uint64
+		if i < len(id) {
+			if l.charIndex[id[i]] == -1 {
+				return 0, false
+			}
+			digit = uint64(l.charIndex[id[i]])
+		}
+		hi, lo := bits.Mul64(v, base)
+		if hi != 0 {
+			return 0, false
+		}
+		if v, hi = bits.Add64(lo, digit, 0); hi != 0 {
+			return 0, false
+		}
+	}
+	return v, true
+}
+
+// FromUint64 encodes the value as an id of blockSize chars, it's the inverse of ToUint64.
+// Values that don't fit in a single block are encoded with as many blocks as needed.
+// Values that are multiples of len(chars) produce ids with a trailing lower char, ToUint64 never returns them for valid ids.
+func (l Lexid) FromUint64(v uint64) string {
+	base := uint64(len(l.chars))
+	var digits []byte
+	for v > 0 {
+		digits = append(digits, l.chars[v%base])
+		v /= base
+	}
+	length := l.blockSize
+	for length < len(digits) {
+		length += l.blockSize
+	}
+	res := make([]byte, length)
+	for i := range res {
+		if j := length - 1 - i; j < len(digits) {
+			res[i] = digits[j]
+		} else {
+			res[i] = l.lower
+		}
+	}
+	return string(res)
+}
+
+// KeyForSeq maps the sequence number to a valid ID of a fixed width, so seq1 < seq2 implies KeyForSeq(seq1) < KeyForSeq(seq2).
+// The width is the number of blocks needed to encode math.MaxUint64 and it's the same for all values.
+// Unlike FromUint64 the keys never end with the lower char: the seq is the index among valid IDs of the width.
+func (l Lexid) KeyForSeq(seq uint64) string {
+	if l.shortGreater {
+		// the transformation reverses the order, so the index is counted from the end
+		r := l.regular()
+		blocks := r.seqBlocks()
+		idx := new(big.Int).Sub(r.validCount(blocks), big.NewInt(1))
+		return l.fromRegular(r.fromValidIndex(idx.Sub(idx, new(big.Int).SetUint64(seq)), blocks))
+	}
+	return l.namespace + l.fromValidIndex(new(big.Int).SetUint64(seq), l.seqBlocks())
+}
+
+// seqBlocks returns the number of blocks that fit math.MaxUint64+1 valid IDs
+func (l Lexid) seqBlocks() int {
+	total := new(big.Int).Lsh(big.NewInt(1), 64)
+	blocks := 1
+	for l.validCount(blocks).Cmp(total) < 0 {
+		blocks++
+	}
+	return blocks
+}
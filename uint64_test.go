@@ -0,0 +1,81 @@
+package lexid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexid_ToUint64(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	t.Run("values", func(t *testing.T) {
+		v, ok := lid.ToUint64("001")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), v)
+		v, ok = lid.ToUint64("010")
+		require.True(t, ok)
+		assert.Equal(t, uint64(36), v)
+		v, ok = lid.ToUint64("zzz")
+		require.True(t, ok)
+		assert.Equal(t, uint64(36*36*36-1), v)
+		v, ok = lid.ToUint64("c")
+		require.True(t, ok)
+		assert.Equal(t, uint64(12*36*36), v)
+	})
+	t.Run("not fit", func(t *testing.T) {
+		_, ok := lid.ToUint64("001001")
+		assert.False(t, ok)
+		_, ok = lid.ToUint64("00A")
+		assert.False(t, ok)
+		_, ok = Must(CharsAlphanumericLower, 13, 1).ToUint64("zzzzzzzzzzzzz")
+		assert.False(t, ok)
+		v, ok := Must("01", 64, 1).ToUint64("1111111111111111111111111111111111111111111111111111111111111111")
+		require.True(t, ok)
+		assert.Equal(t, uint64(math.MaxUint64), v)
+	})
+	t.Run("order", func(t *testing.T) {
+		lid := Must(CharsAlphanumericLower, 3, 7)
+		var prev, next string
+		var prevV uint64
+		for {
+			if next = lid.Next(prev); len(next) > 3 {
+				break
+			}
+			v, ok := lid.ToUint64(next)
+			require.True(t, ok)
+			assert.Greater(t, v, prevV)
+			assert.Equal(t, next, lid.FromUint64(v))
+			prev, prevV = next, v
+		}
+	})
+}
+
+func TestLexid_FromUint64(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	assert.Equal(t, "000", lid.FromUint64(0))
+	assert.Equal(t, "001", lid.FromUint64(1))
+	assert.Equal(t, "zzz", lid.FromUint64(36*36*36-1))
+	assert.Equal(t, "001000", lid.FromUint64(36*36*36))
+}
+
+func TestLexid_KeyForSeq(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 1)
+	// 36^12 < 2^64 < 36^13*35/36
+	assert.Equal(t, "000000000000001", lid.KeyForSeq(0))
+	assert.Equal(t, "00000000000000z", lid.KeyForSeq(34))
+	assert.Equal(t, "000000000000011", lid.KeyForSeq(35))
+	seqs := []uint64{0, 1, 34, 35, 36, 1000, 1 << 32, 1<<63 - 1, 1 << 63, math.MaxUint64 - 1, math.MaxUint64}
+	for i, seq := range seqs {
+		key := lid.KeyForSeq(seq)
+		assert.Len(t, key, 15)
+		assert.True(t, lid.IsValid(key), key)
+		if i > 0 {
+			assert.Less(t, lid.KeyForSeq(seqs[i-1]), key)
+		}
+	}
+	bs1 := Must("01", 1, 1)
+	assert.Len(t, bs1.KeyForSeq(math.MaxUint64), 65)
+	assert.Equal(t, "abc"+lid.KeyForSeq(5), lid.WithNamespace("abc").KeyForSeq(5))
+}
@@ -0,0 +1,102 @@
+package lexid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate returns an error if the id can't be produced by the Lexid:
+// it's empty, its length is not a multiple of blockSize, it contains chars not in the alphabet or ends with the lower char
+func (l Lexid) Validate(id string) error {
+	if l.namespace != "" {
+		tail, err := l.trimNamespace(id)
+		if err != nil {
+			return err
+		}
+		return l.withoutNamespace().Validate(tail)
+	}
+	if l.shortGreater {
+		if !strings.HasSuffix(id, string(l.terminator())) {
+			return fmt.Errorf("%w: '%s' has no terminator", ErrInvalidID, id)
+		}
+		regular := l
+		regular.shortGreater = false
+		return regular.Validate(l.fromShortGreater(id))
+	}
+	if id == "" {
+		return fmt.Errorf("%w: empty id", ErrInvalidID)
+	}
+	if len(id)%l.blockSize != 0 {
+		return fmt.Errorf("%w: length of '%s' is not a multiple of %d", ErrInvalidID, id, l.blockSize)
+	}
+	if err := l.checkChars(id); err != nil {
+		return err
+	}
+	if id[len(id)-1] == l.lower && !l.trailingMin {
+		return fmt.Errorf("%w: '%s' ends with the lower char", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// IsValid reports whether the id can be produced by the Lexid, see Validate.
+// Unlike Validate it never allocates.
+func (l Lexid) IsValid(id string) bool {
+	if l.namespace != "" {
+		if !strings.HasPrefix(id, l.namespace) {
+			return false
+		}
+		id = id[len(l.namespace):]
+	}
+	last := l.lower
+	if l.shortGreater {
+		if len(id) == 0 || id[len(id)-1] != l.terminator() {
+			return false
+		}
+		// the mirrored last char must not be the lower char
+		id = id[:len(id)-1]
+		last = l.upper
+	}
+	if len(id) == 0 || len(id)%l.blockSize != 0 || (id[len(id)-1] == last && !l.trailingMin) {
+		return false
+	}
+	// charIndex is -1 for chars not in the alphabet, so any of them makes the sign bit set
+	var acc int
+	for i := 0; i < len(id); i++ {
+		acc |= l.charIndex[id[i]]
+	}
+	return acc >= 0
+}
+
+// Canonicalize returns the canonical form of the id: with WithCaseFold letters of the wrong case are replaced
+// by the letters of the alphabet, so lookups are case-insensitive and IDs are stored and ordered in one form.
+// It returns an error if a char has no canonical mapping or the canonical id is not valid.
+func (l Lexid) Canonicalize(id string) (string, error) {
+	res := []byte(id)
+	if l.caseFold {
+		for i, c := range res {
+			if l.charIndex[c] == -1 && isLetter(c) && l.charIndex[c^0x20] != -1 {
+				res[i] = c ^ 0x20
+			}
+		}
+	}
+	canonical := string(res)
+	if err := l.Validate(canonical); err != nil {
+		return "", err
+	}
+	return canonical, nil
+}
+
+// ValidateCompound validates a compound key made of IDs joined with sep, like "a0z/001/i01".
+// The separator must not be a char of the alphabet, otherwise segments could not be told apart.
+// The error of an invalid segment reports its index.
+func (l Lexid) ValidateCompound(key string, sep byte) error {
+	if l.charIndex[sep] != -1 {
+		return fmt.Errorf("%w: separator '%c' is a char of the alphabet", ErrInvalidConfig, sep)
+	}
+	for i, segment := range strings.Split(key, string(sep)) {
+		if err := l.Validate(segment); err != nil {
+			return fmt.Errorf("segment %d: %w", i, err)
+		}
+	}
+	return nil
+}
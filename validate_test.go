@@ -0,0 +1,119 @@
+package lexid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexid_Validate(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("valid", func(t *testing.T) {
+		var prev = "zzz"
+		for i := 0; i < 1000; i++ {
+			prev = lid.Next(prev)
+			assert.NoError(t, lid.Validate(prev))
+		}
+		assert.True(t, lid.IsValid(lid.Prev("001")))
+		assert.True(t, lid.IsValid(lid.Middle()))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, id := range []string{"", "01", "0010", "00A", "010", "000"} {
+			assert.ErrorIs(t, lid.Validate(id), ErrInvalidID, id)
+			assert.False(t, lid.IsValid(id), id)
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ns := lid.WithNamespace("abc")
+		assert.True(t, ns.IsValid(ns.Next("")))
+		assert.False(t, ns.IsValid("abd001"))
+		assert.False(t, ns.IsValid("abc"))
+	})
+	t.Run("short greater", func(t *testing.T) {
+		sg := Must(CharsAlphanumericLower, 3, 10, WithShortGreater(true))
+		assert.True(t, sg.IsValid(sg.Next("")))
+		assert.True(t, sg.IsValid(sg.Middle()))
+		assert.False(t, sg.IsValid("001"))
+		assert.False(t, sg.IsValid("zz{"))
+	})
+}
+
+func TestLexid_IsValid(t *testing.T) {
+	lids := []*Lexid{
+		Must(CharsAlphanumericLower, 3, 10),
+		Must(CharsAlphanumericLower, 1, 1),
+		Must(CharsAlphanumericLower, 3, 10).WithNamespace("abc"),
+		Must(CharsAlphanumericLower, 2, 3, WithShortGreater(true)),
+	}
+	for _, lid := range lids {
+		ids := []string{"", "0", "00", "01", "001", "010", "00A", "abc", "abc001", "abc000", "abcA01", "zz", "z0", "zzz"}
+		var prev string
+		for i := 0; i < 100; i++ {
+			prev = lid.Next(prev)
+			ids = append(ids, prev, lid.Prev(prev), prev+"0", prev[:len(prev)-1])
+		}
+		ids = append(ids, lid.Middle())
+		for _, id := range ids {
+			assert.Equal(t, lid.Validate(id) == nil, lid.IsValid(id), id)
+		}
+	}
+	lid := lids[0]
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		lid.IsValid("abc00A")
+		lid.IsValid("abcdef")
+	}))
+}
+
+func BenchmarkLexid_IsValid(b *testing.B) {
+	lid := Must(CharsAlphanumericLower, 4, 1)
+	id := "abcd0123efgh4567ijkl"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !lid.IsValid(id) {
+			b.Fatal("invalid")
+		}
+	}
+}
+
+func TestLexid_Canonicalize(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10, WithCaseFold())
+	t.Run("fold", func(t *testing.T) {
+		id, err := lid.Canonicalize("A0Z001")
+		assert.NoError(t, err)
+		assert.Equal(t, "a0z001", id)
+		id, err = lid.Canonicalize("a0z001")
+		assert.NoError(t, err)
+		assert.Equal(t, "a0z001", id)
+	})
+	t.Run("no mapping", func(t *testing.T) {
+		_, err := lid.Canonicalize("a-z001")
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("without fold", func(t *testing.T) {
+		_, err := Must(CharsAlphanumericLower, 3, 10).Canonicalize("A0Z001")
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+	t.Run("both cases", func(t *testing.T) {
+		_, err := New(CharsAlphanumeric, 3, 10, WithCaseFold())
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestLexid_ValidateCompound(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, lid.ValidateCompound("a0z/001/i00001", '/'))
+		assert.NoError(t, lid.ValidateCompound("001", '/'))
+	})
+	t.Run("invalid segment", func(t *testing.T) {
+		err := lid.ValidateCompound("a0z/000/i00", '/')
+		assert.ErrorIs(t, err, ErrInvalidID)
+		assert.Contains(t, err.Error(), "segment 1")
+		err = lid.ValidateCompound("a0z//i00", '/')
+		assert.ErrorIs(t, err, ErrInvalidID)
+		assert.Contains(t, err.Error(), "segment 1")
+	})
+	t.Run("separator in alphabet", func(t *testing.T) {
+		assert.ErrorIs(t, lid.ValidateCompound("a0zai00", 'a'), ErrInvalidConfig)
+	})
+}
@@ -0,0 +1,32 @@
+package lexid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteN writes count successive IDs after start to w, separated by sep, and returns the last written ID.
+// The separator must not be in the alphabet, otherwise the output can't be split back into IDs.
+func (l Lexid) WriteN(w io.Writer, start string, count int, sep byte) (last string, err error) {
+	if l.charIndex[sep] != -1 {
+		return "", fmt.Errorf("%w: separator '%c' is in the alphabet", ErrInvalidConfig, sep)
+	}
+	bw := bufio.NewWriter(w)
+	last = start
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			if err = bw.WriteByte(sep); err != nil {
+				return "", err
+			}
+		}
+		last = l.Next(last)
+		if _, err = bw.WriteString(last); err != nil {
+			return "", err
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		return "", err
+	}
+	return last, nil
+}
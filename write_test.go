@@ -0,0 +1,47 @@
+package lexid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestLexid_WriteN(t *testing.T) {
+	lid := Must(CharsAlphanumericLower, 3, 10)
+	t.Run("write", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		last, err := lid.WriteN(buf, "", 10000, '\n')
+		require.NoError(t, err)
+		ids := strings.Split(buf.String(), "\n")
+		require.Len(t, ids, 10000)
+		var prev string
+		for _, id := range ids {
+			assert.Equal(t, lid.Next(prev), id)
+			prev = id
+		}
+		assert.Equal(t, prev, last)
+	})
+	t.Run("zero count", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		last, err := lid.WriteN(buf, "001", 0, '\n')
+		require.NoError(t, err)
+		assert.Equal(t, "001", last)
+		assert.Empty(t, buf.String())
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := lid.WriteN(bytes.NewBuffer(nil), "", 10, 'a')
+		assert.Error(t, err)
+		_, err = lid.WriteN(errWriter{}, "", 10, ',')
+		assert.Error(t, err)
+	})
+}